@@ -36,13 +36,38 @@ type Context struct {
 
 	errors []error
 	queue  taskQueue
+
+	parent *Context
+	scope  string
+}
+
+// Scope returns a context that adds its errors and tasks to ctx,
+// prefixing error messages with the given description of where they come from.
+func (ctx *Context) Scope(format string, args ...interface{}) *Context {
+	return &Context{
+		Schema: ctx.Schema,
+		parent: ctx,
+		scope:  fmt.Sprintf(format, args...),
+	}
 }
 
 func (ctx *Context) AddError(message string, args ...interface{}) {
-	ctx.errors = append(ctx.errors, fmt.Errorf(message, args...))
+	ctx.addError(fmt.Errorf(message, args...))
+}
+
+func (ctx *Context) addError(err error) {
+	if ctx.parent != nil {
+		ctx.parent.addError(fmt.Errorf("%s: %w", ctx.scope, err))
+		return
+	}
+	ctx.errors = append(ctx.errors, err)
 }
 
 func (ctx *Context) Enqueue(order int, fn func()) {
+	if ctx.parent != nil {
+		ctx.parent.Enqueue(order, fn)
+		return
+	}
 	heap.Push(&ctx.queue, task{order, fn})
 }
 func (ctx *Context) Run() {
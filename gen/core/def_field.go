@@ -42,7 +42,11 @@ type defField struct {
 	typeName string
 	items    []FieldItem
 
-	field *schema.Field // Filled on the ModelItem pass, used in the ModelRecursiveItem pass
+	field *schema.Field // Filled on the StructItem pass, used in the ModelRecursiveItem pass
+
+	// Filled on the ModelItem pass, used in the ModelRecursiveItem pass. Keyed by
+	// model, because a field defined in a mixin is added to every model using it.
+	modelFields map[*schema.Model]*schema.Field
 }
 
 func (d *defField) StructItem(ctx *StructContext) {
@@ -80,7 +84,10 @@ func (d *defField) ModelItem(ctx *ModelContext) {
 		Tags:     schema.Tags{},
 	}
 	m.Fields = append(m.Fields, f)
-	d.field = f
+	if d.modelFields == nil {
+		d.modelFields = make(map[*schema.Model]*schema.Field)
+	}
+	d.modelFields[m] = f
 
 	for _, i := range d.items {
 		if i, ok := i.(ModelFieldItem); ok {
@@ -94,6 +101,9 @@ func (d *defField) ModelItem(ctx *ModelContext) {
 
 func (d *defField) ModelRecursiveItem(ctx *ModelRecursiveContext) {
 	f := d.field
+	if len(ctx.Prefix) == 0 {
+		f = d.modelFields[ctx.Model]
+	}
 
 	for _, i := range d.items {
 		if i, ok := i.(ModelRecursiveFieldItem); ok {
@@ -109,7 +119,7 @@ func (d *defField) ModelRecursiveItem(ctx *ModelRecursiveContext) {
 		return
 	}
 
-	if t, ok := t.(*schema.Struct); ok {
+	if t, ok := t.(*schema.Struct); ok && !f.IsJSON() {
 		defStruct := t.GetExtension(defStructExt{}).(*structType)
 
 		ctx2 := &ModelRecursiveContext{
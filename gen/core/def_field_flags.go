@@ -49,3 +49,35 @@ var _ StructFieldItem = defFieldTag{}
 func Tag(key string, value string) defFieldTag {
 	return defFieldTag{key: key, value: value}
 }
+
+type defAsJSON struct{}
+
+func (d defAsJSON) FieldItem() {}
+
+func (d defAsJSON) ModelFieldItem(ctx *ModelFieldContext) {
+	if !ctx.Field.IsStruct() {
+		ctx.AddError("model %s field %s: AsJSON can only be used on fields of struct type", ctx.Model.Name, ctx.Field.Name)
+	}
+	ctx.Field.JSON = true
+}
+
+func (d defAsJSON) StructFieldItem(ctx *StructFieldContext) {
+	if ctx.Field.Type != nil && !ctx.Field.IsStruct() {
+		ctx.AddError("struct %s field %s: AsJSON can only be used on fields of struct type", ctx.Struct.Name, ctx.Field.Name)
+	}
+	ctx.Field.JSON = true
+}
+
+func (d defAsJSON) StructItem(ctx *StructContext) {
+	ctx.Struct.JSON = true
+}
+
+var _ FieldItem = defAsJSON{}
+var _ ModelFieldItem = defAsJSON{}
+var _ StructFieldItem = defAsJSON{}
+var _ StructItem = defAsJSON{}
+
+// AsJSON stores a struct field in a single jsonb column, instead of flattening
+// it into one column per struct field. Used in a Struct definition, it makes
+// all fields of that struct type be stored as JSON.
+var AsJSON defAsJSON
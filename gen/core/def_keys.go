@@ -42,6 +42,28 @@ var PrimaryKey defFieldPrimaryKey = func(names ...string) defModelPrimaryKey {
 	return defModelPrimaryKey{names: names}
 }
 
+type defModelPaginateBy struct {
+	names []string
+}
+
+func (d defModelPaginateBy) ModelItem(ctx *ModelContext) {}
+func (d defModelPaginateBy) ModelRecursiveItem(ctx *ModelRecursiveContext) {
+	m := ctx.Model
+	if m.PaginateBy != nil {
+		ctx.AddError("Model '%s' has multiple pagination key definitions", m.Name)
+	}
+	m.PaginateBy = parsePathsPrefix(ctx, ctx.Prefix, d.names)
+}
+
+var _ ModelItem = defModelPaginateBy{}
+var _ ModelRecursiveItem = defModelPaginateBy{}
+
+// PaginateBy sets the fields the generated Paginate finisher orders by. They must
+// be unique and not nullable. Defaults to the primary key.
+func PaginateBy(names ...string) defModelPaginateBy {
+	return defModelPaginateBy{names: names}
+}
+
 type defModelIndex struct {
 	names  []string
 	where  string
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"github.com/sqlbunny/sqlbunny/gen"
+	"github.com/sqlbunny/sqlbunny/schema"
+)
+
+type defMixin struct {
+	name  string
+	items []ModelItem
+
+	expanding bool // Set while the mixin is being expanded, to detect cycles.
+}
+
+type mixinsExt struct{}
+
+// mixinExt is the extension key for the name of the mixin that introduced
+// a field, primary key, index, unique or foreign key.
+type mixinExt struct{}
+
+func getMixins(ctx *gen.Context) map[string]*defMixin {
+	mixins, _ := ctx.Schema.GetExtension(mixinsExt{}).(map[string]*defMixin)
+	if mixins == nil {
+		mixins = make(map[string]*defMixin)
+		ctx.Schema.SetExtension(mixinsExt{}, mixins)
+	}
+	return mixins
+}
+
+func (d *defMixin) ConfigItem(ctx *gen.Context) {
+	mixins := getMixins(ctx)
+	if _, ok := mixins[d.name]; ok {
+		ctx.AddError("Mixin '%s' is defined multiple times", d.name)
+	}
+	mixins[d.name] = d
+}
+
+var _ gen.ConfigItem = &defMixin{}
+
+// Mixin defines a named set of model items (fields, indexes, foreign keys, relationships...)
+// that can be included in any model with Use. Unlike Struct, the items are added to the model
+// as if they had been defined in it directly, so field names are not prefixed.
+func Mixin(name string, items ...ModelItem) gen.ConfigItem {
+	return &defMixin{
+		name:  name,
+		items: items,
+	}
+}
+
+type defUse struct {
+	name string
+}
+
+func (d defUse) mixin(ctx *gen.Context, m *schema.Model) *defMixin {
+	mixin, ok := getMixins(ctx)[d.name]
+	if !ok {
+		ctx.AddError("Model '%s' uses unknown mixin '%s'", m.Name, d.name)
+		return nil
+	}
+	return mixin
+}
+
+func (d defUse) ModelItem(ctx *ModelContext) {
+	m := ctx.Model
+	mixin := d.mixin(ctx.Context, m)
+	if mixin == nil {
+		return
+	}
+	if mixin.expanding {
+		ctx.AddError("Model '%s' uses mixin '%s' recursively", m.Name, d.name)
+		return
+	}
+	mixin.expanding = true
+	defer func() { mixin.expanding = false }()
+
+	ctx2 := &ModelContext{
+		Context: ctx.Scope("Mixin '%s'", d.name),
+		Model:   m,
+	}
+
+	n := len(m.Fields)
+	for _, i := range mixin.items {
+		i.ModelItem(ctx2)
+	}
+	for _, f := range m.Fields[n:] {
+		markMixin(&f.Extendable, d.name)
+	}
+}
+
+func (d defUse) ModelRecursiveItem(ctx *ModelRecursiveContext) {
+	m := ctx.Model
+	mixin := d.mixin(ctx.Context, m)
+	if mixin == nil || mixin.expanding {
+		// Errors have already been reported in the ModelItem pass.
+		return
+	}
+	mixin.expanding = true
+	defer func() { mixin.expanding = false }()
+
+	ctx2 := &ModelRecursiveContext{
+		Context:       ctx.Scope("Mixin '%s'", d.name),
+		Model:         m,
+		Prefix:        ctx.Prefix,
+		ForceNullable: ctx.ForceNullable,
+	}
+
+	pk := m.PrimaryKey
+	nIndexes, nUniques, nForeignKeys := len(m.Indexes), len(m.Uniques), len(m.ForeignKeys)
+	for _, i := range mixin.items {
+		if i, ok := i.(ModelRecursiveItem); ok {
+			i.ModelRecursiveItem(ctx2)
+		}
+	}
+	if m.PrimaryKey != nil && m.PrimaryKey != pk {
+		markMixin(&m.PrimaryKey.Extendable, d.name)
+	}
+	for _, x := range m.Indexes[nIndexes:] {
+		markMixin(&x.Extendable, d.name)
+	}
+	for _, x := range m.Uniques[nUniques:] {
+		markMixin(&x.Extendable, d.name)
+	}
+	for _, x := range m.ForeignKeys[nForeignKeys:] {
+		markMixin(&x.Extendable, d.name)
+	}
+}
+
+var _ ModelItem = defUse{}
+var _ ModelRecursiveItem = defUse{}
+
+// Use includes the items of the named mixin in the model.
+func Use(name string) defUse {
+	return defUse{name: name}
+}
+
+// markMixin records that an item was introduced by the mixin. Items from
+// nested mixins keep the name of the innermost one.
+func markMixin(e *schema.Extendable, name string) {
+	if e.GetExtension(mixinExt{}) == nil {
+		e.SetExtension(mixinExt{}, name)
+	}
+}
+
+// fromMixin describes the mixin that introduced an item, for error messages.
+func fromMixin(e *schema.Extendable) string {
+	if name, ok := e.GetExtension(mixinExt{}).(string); ok {
+		return " (from mixin '" + name + "')"
+	}
+	return ""
+}
@@ -41,6 +41,7 @@ type {{$modelNameCamel}}R struct {
 	{{range .Model.Relationships -}}
 	{{- if .ToMany -}}
 	{{ .Name | titleCase }} {{ .ForeignModel | titleCase}}Slice
+	{{ .Name | titleCase }}Count int64
 	{{ else -}}
 	{{ .Name | titleCase }} *{{ .ForeignModel | titleCase}}
 	{{ end -}}
@@ -4,6 +4,8 @@ var (
 	{{$varNameSingular}}Columns               = []string{{"{"}}{{modelColumns      .Model | stringMap .StringFuncs.quoteWrap | join ", "}}{{"}"}}
 	{{$varNameSingular}}PrimaryKeyColumns     = []string{{"{"}}{{modelPKColumns    .Model | stringMap .StringFuncs.quoteWrap | join ", "}}{{"}"}}
 	{{$varNameSingular}}NonPrimaryKeyColumns  = []string{{"{"}}{{modelNonPKColumns .Model | stringMap .StringFuncs.quoteWrap | join ", "}}{{"}"}}
+	{{$varNameSingular}}ByteaColumns          = []string{{"{"}}{{modelByteaColumns .Model | stringMap .StringFuncs.quoteWrap | join ", "}}{{"}"}}
+	{{$varNameSingular}}ColumnTypes           = map[string]string{{"{"}}{{range $name, $column := .Model.Table.Columns}}{{printf "%q" $name}}: {{printf "%q" $column.Type}}, {{end}}{{"}"}}
 )
 
 type (
@@ -25,4 +27,8 @@ var (
 	{{$varNameSingular}}InsertCache = make(map[string]insertCache)
 	{{$varNameSingular}}UpdateCacheMut sync.RWMutex
 	{{$varNameSingular}}UpdateCache = make(map[string]updateCache)
+	{{$varNameSingular}}UpdateAllCacheMut sync.RWMutex
+	{{$varNameSingular}}UpdateAllCache = make(map[string]updateCache)
+	{{$varNameSingular}}UpsertCacheMut sync.RWMutex
+	{{$varNameSingular}}UpsertCache = make(map[string]upsertCache)
 )
@@ -0,0 +1,24 @@
+{{- $dot := . -}}
+{{- $modelName := .Model.Name | titleCase -}}
+// {{$modelName}}Where has typed where clause helpers for the {{$modelName}} columns.
+// Use their As method in queries aliasing the table, like the loaders of qm.LoadWith.
+var {{$modelName}}Where = struct {
+	{{range $column := modelColumnTypes .Model -}}
+	{{titleCase $column.Name}} {{if $column.IsString}}qm.StringWhereHelper{{else}}qm.WhereHelper{{end}}[{{goType $column.GoType}}]
+	{{end -}}
+}{
+	{{range $column := modelColumnTypes .Model -}}
+	{{titleCase $column.Name}}: {{if $column.IsString}}qm.NewStringWhereHelper{{else}}qm.NewWhereHelper{{end}}[{{goType $column.GoType}}]("{{$dot.Model.Name | schemaModel}}", "{{quotes $column.Name}}"),
+	{{end -}}
+}
+
+// {{$modelName}}OrderBy has typed order by clause helpers for the {{$modelName}} columns.
+var {{$modelName}}OrderBy = struct {
+	{{range $column := modelColumnTypes .Model -}}
+	{{titleCase $column.Name}} qm.OrderByHelper
+	{{end -}}
+}{
+	{{range $column := modelColumnTypes .Model -}}
+	{{titleCase $column.Name}}: qm.NewOrderByHelper("{{$dot.Model.Name | schemaModel}}", "{{quotes $column.Name}}"),
+	{{end -}}
+}
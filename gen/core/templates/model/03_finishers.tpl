@@ -1,3 +1,4 @@
+{{ import "iter" "iter" }}
 {{- $modelNameSingular := .Model.Name | singular | titleCase -}}
 {{- $varNameSingular := .Model.Name | singular | camelCase -}}
 // One returns a single {{$varNameSingular}} record from the query. If the query returns no objects, ErrNoRows is returned. 
@@ -20,9 +21,10 @@ func (q {{$varNameSingular}}Query) One(ctx context.Context) (*{{$modelNameSingul
 func (q {{$varNameSingular}}Query) First(ctx context.Context) (*{{$modelNameSingular}}, error) {
 	o := &{{$modelNameSingular}}{}
 
-	queries.SetLimit(q.Query, 1)
+	query := q.Query.Clone()
+	queries.SetLimit(query, 1)
 
-	err := q.Bind(ctx, o)
+	err := query.Bind(ctx, o)
 	if err != nil {
 		return nil, errors.Errorf("{{.PkgName}}: failed to execute a one query for {{.Model.Name}}: %w", err)
 	}
@@ -46,14 +48,59 @@ func (q {{$varNameSingular}}Query) All(ctx context.Context) ({{$modelNameSingula
 	return o, nil
 }
 
+// EachBatch calls fn with the {{$modelNameSingular}} records from the query in batches of at most size records,
+// reading them from the database as they're needed instead of loading all of them in memory.
+// Relationships set with qm.Load are eager loaded for each batch.
+// If fn returns an error, the iteration is stopped and the error returned.
+func (q {{$varNameSingular}}Query) EachBatch(ctx context.Context, size int, fn func({{$modelNameSingular}}Slice) error) error {
+	return queries.BindBatches(ctx, q.Query, size, func(o []*{{$modelNameSingular}}) error {
+		{{ hook . "after_select_slice_noreturn" "o" .Model }}
+
+		return fn(o)
+	})
+}
+
+// Each calls fn for each {{$modelNameSingular}} record from the query, reading them from the database
+// as they're needed. See EachBatch, the records are read in batches of queries.DefaultBatchSize.
+func (q {{$varNameSingular}}Query) Each(ctx context.Context, fn func(*{{$modelNameSingular}}) error) error {
+	return q.EachBatch(ctx, queries.DefaultBatchSize, func(o {{$modelNameSingular}}Slice) error {
+		for _, obj := range o {
+			if err := fn(obj); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Iter returns an iterator over the {{$modelNameSingular}} records from the query. See Each.
+// If the query fails, the error is yielded and the iteration stops.
+func (q {{$varNameSingular}}Query) Iter(ctx context.Context) iter.Seq2[*{{$modelNameSingular}}, error] {
+	return func(yield func(*{{$modelNameSingular}}, error) bool) {
+		err := q.Each(ctx, func(o *{{$modelNameSingular}}) error {
+			if !yield(o, nil) {
+				return errStopIteration
+			}
+			return nil
+		})
+		if err != nil && err != errStopIteration {
+			yield(nil, errors.Errorf("{{.PkgName}}: failed to iterate {{.Model.Name}} rows: %w", err))
+		}
+	}
+}
+
 // Count returns the count of all {{$modelNameSingular}} records in the query.
 func (q {{$varNameSingular}}Query) Count(ctx context.Context) (int64, error) {
 	var count int64
 
-	queries.SetSelect(q.Query, nil)
-	queries.SetCount(q.Query)
+	query := q.Query.Clone()
+	if !queries.HasCombinations(query) {
+		// The combined queries must keep selecting the same columns, they're counted as a subquery.
+		queries.SetSelect(query, nil)
+	}
+	queries.SetCount(query)
 
-	err := q.Query.QueryRow(ctx).Scan(&count)
+	err := query.QueryRow(ctx).Scan(&count)
 	if err != nil {
 		return 0, errors.Errorf("{{.PkgName}}: failed to count {{.Model.Name}} rows: %w", err)
 	}
@@ -65,10 +112,11 @@ func (q {{$varNameSingular}}Query) Count(ctx context.Context) (int64, error) {
 func (q {{$varNameSingular}}Query) Exists(ctx context.Context) (bool, error) {
 	var count int64
 
-	queries.SetCount(q.Query)
-	queries.SetLimit(q.Query, 1)
+	query := q.Query.Clone()
+	queries.SetCount(query)
+	queries.SetLimit(query, 1)
 
-	err := q.Query.QueryRow(ctx).Scan(&count)
+	err := query.QueryRow(ctx).Scan(&count)
 	if err != nil {
 		return false, errors.Errorf("{{.PkgName}}: failed to check if {{.Model.Name}} exists: %w", err)
 	}
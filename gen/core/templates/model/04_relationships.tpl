@@ -43,125 +43,108 @@ func (o *{{$modelName}}) {{$relationshipName}}(mods ...qm.QueryMod) ({{$foreignM
 }
 
 // Load{{$relationshipName}} allows an eager lookup of values, cached into the
-// loaded structs of the objects.
-func ({{$modelNameCamel}}L) Load{{$relationshipName}}(ctx context.Context, slice []*{{$modelName}}) error {
-	args := make([]interface{}, len(slice)*{{len .LocalFields}})
-	for i, obj := range slice {
+// loaded structs of the objects. mods are applied to the query loading them.
+func ({{$modelNameCamel}}L) Load{{$relationshipName}}(ctx context.Context, slice []*{{$modelName}}, mods ...qm.QueryMod) error {
+	// Group the objects by key, and collect the distinct keys as arguments.
+	locals := make(map[interface{}][]*{{$modelName}})
+	var args []interface{}
+	for _, obj := range slice {
 		if obj.R == nil {
 			obj.R = &{{$modelNameCamel}}R{}
 		}
-		{{ range $i, $c := .LocalFields }}
-		args[i*{{len $relationship.LocalFields}} + {{$i}}] = obj.{{$c | titleCasePath}}
-		{{ end }}
-	}
-
-	if len(args) == 0 {
-		return nil
-	}
-
-	{{if .IsJoinModel }}
-	{{ $joinModel := index $dot.Schema.Models .JoinModel }}
-	{{- $joinModelName := .JoinModel | titleCase}}
-	{{- $joinModelNameCamel := .JoinModel | camelCase}}
-
-	where := fmt.Sprintf(
-		"{{ whereInClause $dot.LQ $dot.RQ "j" .JoinLocalFields }} in (%s)",
-		strmangle.Placeholders(dialect.IndexPlaceholders, len(slice)*{{len .LocalFields}}, 1, {{len .LocalFields}}),
-	)
-	query := NewQuery(
-		qm.Select(
-			{{ range $i, $c := $foreignModel.Table.Columns -}}"f.{{$i}}",{{end}}
-			{{ range $i, $c := .JoinLocalFields -}}{{if $i}},{{end}} "j.{{$c}}"{{end}},
-		),
-		qm.From("{{.ForeignModel | schemaModel}} AS f"),
-		qm.InnerJoin("{{.JoinModel | schemaModel }} AS j ON {{joinOnClause $dot.LQ $dot.RQ "j" .JoinForeignFields "f" .ForeignFields}}"),
-		qm.Where(where, args...),
-		{{if .ForeignWhere -}}
-		{{- $schemaModel := .ForeignModel | schemaModel }}
-		qm.Where("{{replaceAll .ForeignWhere "f" $schemaModel}}"),
-		{{- end }}
-		{{if .ForeignOrderBy -}}
-		qm.OrderBy("{{.ForeignOrderBy}}"),
+		{{ range .LocalFields -}}
+		{{- if ($model.FindField .).Nullable }}
+		if !obj.{{. | titleCasePath}}.Valid {
+			continue
+		}
+		{{- end}}
 		{{- end }}
-	)
-	type joinStruct struct {
-		F {{ $foreignModelName }} `bunny:"f.,bind"`
-		J {{ $joinModelName }} `bunny:"j.,bind"`
-	}
-	var resultSlice []*joinStruct
-	if err := query.Bind(ctx, &resultSlice); err != nil {
-		return errors.Errorf("failed to bind eager loaded slice {{$foreignModelName}}: %w", err)
+		key := {{loadKey "obj" .LocalFields $model}}
+		if _, ok := locals[key]; !ok {
+			args = append(args{{range .LocalFields}}, obj.{{. | titleCasePath}}{{end}})
+		}
+		locals[key] = append(locals[key], obj)
 	}
 
-	if len(resultSlice) == 0 {
-		return nil
-	}
+	for _, chunk := range queries.LoadChunks(args, {{len .LocalFields}}) {
+
+		{{if .IsJoinModel -}}
+		{{ $joinModel := index $dot.Schema.Models .JoinModel }}
+		{{- $joinModelName := .JoinModel | titleCase}}
+		where := fmt.Sprintf(
+			"{{ whereInClause $dot.LQ $dot.RQ "j" .JoinLocalFields }} in (%s)",
+			strmangle.Placeholders(false, len(chunk), 1, {{len .LocalFields}}),
+		)
+		queryMods := []qm.QueryMod{
+			qm.Select(
+				{{ range $i, $c := $foreignModel.Table.Columns -}}"f.{{$i}}",{{end}}
+				{{ range $i, $c := .JoinLocalFields -}}{{if $i}},{{end}} "j.{{$c.SQLName}}"{{end}},
+			),
+			qm.From("{{.ForeignModel | schemaModel}} AS f"),
+			qm.InnerJoin("{{.JoinModel | schemaModel }} AS j ON {{joinOnClause $dot.LQ $dot.RQ "j" .JoinForeignFields "f" .ForeignFields}}"),
+			qm.Where(where, chunk...),
+			{{if .ForeignWhere -}}
+			qm.Where("{{replaceAll .ForeignWhere "$foreign" "f"}}"),
+			{{- end }}
+			{{if .ForeignOrderBy -}}
+			qm.OrderBy("{{.ForeignOrderBy}}"),
+			{{- end }}
+		}
+		query := NewQuery(append(queryMods, mods...)...)
+		type joinStruct struct {
+			F {{ $foreignModelName }} `bunny:"f.,bind"`
+			J {{ $joinModelName }} `bunny:"j.,bind"`
+		}
+		var resultSlice []*joinStruct
+		if err := query.Bind(ctx, &resultSlice); err != nil {
+			return errors.Errorf("failed to bind eager loaded slice {{$foreignModelName}}: %w", err)
+		}
 
-	for _, local := range slice {
 		for _, joined := range resultSlice {
-			if {{ range $i, $lc := .LocalFields -}}
-				{{- if $i}} && {{end}}
-				{{- $jc := index $relationship.JoinLocalFields $i -}}
-				{{- $lcol := $model.FindField $lc -}}
-				{{- $jcol := $joinModel.FindField $jc -}}
-				{{doCompare (printf "local.%s" ($lc | titleCasePath)) (printf "joined.J.%s" ($jc | titleCasePath)) $lcol $jcol }}
-			{{- end }} {
-				{{if .ToMany}}
+			for _, local := range locals[{{loadKey "joined.J" .JoinLocalFields $joinModel}}] {
+				{{if .ToMany -}}
 				local.R.{{$relationshipName}} = append(local.R.{{$relationshipName}}, &joined.F)
-				{{else}}
+				{{- else -}}
 				local.R.{{$relationshipName}} = &joined.F
-				{{end}}
+				{{- end}}
 			}
 		}
-	}
-	{{else}}
-	where := fmt.Sprintf(
-		"{{ whereInClause $dot.LQ $dot.RQ "f" .ForeignFields }} in (%s)",
-		strmangle.Placeholders(dialect.IndexPlaceholders, len(slice)*{{len .LocalFields}}, 1, {{len .LocalFields}}),
-	)
-	query := NewQuery(
-		qm.Select("f.*"),
-		qm.From("{{.ForeignModel | schemaModel}} AS f"),
-		qm.Where(where, args...),
-		{{if .ForeignWhere -}}
-		{{- $schemaModel := .ForeignModel | schemaModel }}
-		qm.Where("{{replaceAll .ForeignWhere "$foreign" "f"}}"),
-		{{- end }}
-		{{if .ForeignOrderBy -}}
-		qm.OrderBy("{{.ForeignOrderBy}}"),
-		{{- end }}
-	)
-
-	var resultSlice []*{{$foreignModelName}}
-	if err := query.Bind(ctx, &resultSlice); err != nil {
-		return errors.Errorf("failed to bind eager loaded slice {{$foreignModelName}}: %w", err)
-	}
+		{{- else -}}
+		where := fmt.Sprintf(
+			"{{ whereInClause $dot.LQ $dot.RQ "f" .ForeignFields }} in (%s)",
+			strmangle.Placeholders(false, len(chunk), 1, {{len .LocalFields}}),
+		)
+		queryMods := []qm.QueryMod{
+			qm.Select("f.*"),
+			qm.From("{{.ForeignModel | schemaModel}} AS f"),
+			qm.Where(where, chunk...),
+			{{if .ForeignWhere -}}
+			qm.Where("{{replaceAll .ForeignWhere "$foreign" "f"}}"),
+			{{- end }}
+			{{if .ForeignOrderBy -}}
+			qm.OrderBy("{{.ForeignOrderBy}}"),
+			{{- end }}
+		}
+		query := NewQuery(append(queryMods, mods...)...)
 
-	{{ hook $dot "after_select_slice_noreturn" "resultSlice" $foreignModel }}
+		var resultSlice []*{{$foreignModelName}}
+		if err := query.Bind(ctx, &resultSlice); err != nil {
+			return errors.Errorf("failed to bind eager loaded slice {{$foreignModelName}}: %w", err)
+		}
 
-	if len(resultSlice) == 0 {
-		return nil
-	}
+		{{ hook $dot "after_select_slice_noreturn" "resultSlice" $foreignModel }}
 
-	for _, local := range slice {
 		for _, foreign := range resultSlice {
-			if {{ range $i, $lc := .LocalFields -}}
-				{{- if $i}} && {{end}}
-				{{- $fc := index $relationship.ForeignFields $i -}}
-				{{- $lcol := $model.FindField $lc -}}
-				{{- $fcol := $foreignModel.FindField $fc -}}
-				{{doCompare (printf "local.%s" ($lc | titleCasePath)) (printf "foreign.%s" ($fc | titleCasePath)) $lcol $fcol }}
-			{{- end }} {
-				{{if .ToMany}}
+			for _, local := range locals[{{loadKey "foreign" .ForeignFields $foreignModel}}] {
+				{{if .ToMany -}}
 				local.R.{{$relationshipName}} = append(local.R.{{$relationshipName}}, foreign)
-				{{else}}
+				{{- else -}}
 				local.R.{{$relationshipName}} = foreign
-				break
-				{{end}}
+				{{- end}}
 			}
 		}
+		{{- end}}
 	}
-	{{end}}
 
 	return nil
 }
@@ -0,0 +1,283 @@
+{{ import "slices" "slices" }}
+{{- $dot := . -}}
+{{- $model := .Model -}}
+{{- $modelName := .Model.Name | titleCase -}}
+{{- $modelNameCamel := .Model.Name | camelCase -}}
+
+{{ range .Model.Relationships -}}
+
+{{- $rel := . }}
+{{- $relationshipName := .Name | titleCase}}
+{{- $foreignModel := index $dot.Schema.Models .ForeignModel }}
+{{- $foreignModelName := .ForeignModel | titleCase}}
+{{- $foreignModelNameCamel := .ForeignModel | camelCase}}
+{{- $reverse := $foreignModel.FindReverseRelationship $model.Name $rel }}
+{{- $sameRelated := "rel == obj" }}
+{{- with $foreignModel.PrimaryKey }}{{ $sameRelated = printf "%s == %s" (loadKey "rel" .Fields $foreignModel) (loadKey "obj" .Fields $foreignModel) }}{{ end }}
+{{- $sameLocal := "obj == o" }}
+{{- with $model.PrimaryKey }}{{ $sameLocal = printf "%s == %s" (loadKey "obj" .Fields $model) (loadKey "o" .Fields $model) }}{{ end }}
+
+{{- if .IsJoinModel }}
+{{- $joinModel := index $dot.Schema.Models .JoinModel }}
+{{- $joinModelName := .JoinModel | titleCase}}
+
+// Add{{$relationshipName}} adds related to the {{$relationshipName}} relationship of o, inserting
+// the {{.JoinModel}} rows joining them, and appends them to o.R.{{$relationshipName}}.
+// If insert is set, the related objects are inserted first.
+func (o *{{$modelName}}) Add{{$relationshipName}}(ctx context.Context, insert bool, related ...*{{$foreignModelName}}) error {
+	for _, rel := range related {
+		if insert {
+			if err := rel.Insert(ctx); err != nil {
+				return errors.Errorf("{{$dot.PkgName}}: failed to insert into foreign table: %w", err)
+			}
+		}
+
+		join := &{{$joinModelName}}{}
+		{{range $i, $lc := .LocalFields -}}
+		{{- $jc := index $rel.JoinLocalFields $i -}}
+		{{doAssign (printf "join.%s" ($jc | titleCasePath)) (printf "o.%s" ($lc | titleCasePath)) ($joinModel.FindField $jc) ($model.FindField $lc)}}
+		{{end -}}
+		{{range $i, $fc := .ForeignFields -}}
+		{{- $jc := index $rel.JoinForeignFields $i -}}
+		{{doAssign (printf "join.%s" ($jc | titleCasePath)) (printf "rel.%s" ($fc | titleCasePath)) ($joinModel.FindField $jc) ($foreignModel.FindField $fc)}}
+		{{end -}}
+		if err := join.Insert(ctx); err != nil {
+			return errors.Errorf("{{$dot.PkgName}}: failed to insert into join table: %w", err)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &{{$modelNameCamel}}R{}
+	}
+	o.R.{{$relationshipName}} = append(o.R.{{$relationshipName}}, related...)
+	{{with $reverse -}}
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &{{$foreignModelNameCamel}}R{}
+		}
+		{{if .ToMany}}rel.R.{{.Name | titleCase}} = append(rel.R.{{.Name | titleCase}}, o){{else}}rel.R.{{.Name | titleCase}} = o{{end}}
+	}
+	{{- end}}
+
+	return nil
+}
+
+// Remove{{$relationshipName}} removes related from the {{$relationshipName}} relationship of o, deleting
+// the {{.JoinModel}} rows joining them, and removes them from o.R.{{$relationshipName}}.
+func (o *{{$modelName}}) Remove{{$relationshipName}}(ctx context.Context, related ...*{{$foreignModelName}}) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	args := []interface{}{ {{- range $i, $lc := .LocalFields}}{{if $i}}, {{end}}o.{{$lc | titleCasePath}}{{end -}} }
+	for _, rel := range related {
+		args = append(args{{range .ForeignFields}}, rel.{{. | titleCasePath}}{{end}})
+	}
+
+	sql := "DELETE FROM {{.JoinModel | schemaModel}} WHERE {{if $dot.Dialect.IndexPlaceholders}}{{whereClause $dot.LQ $dot.RQ 1 .JoinLocalFields}}{{else}}{{whereClause $dot.LQ $dot.RQ 0 .JoinLocalFields}}{{end}} AND " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), {{if $dot.Dialect.IndexPlaceholders}}{{len .JoinLocalFields}}+1{{else}}0{{end}}, []string{{"{"}}{{sqlNames .JoinForeignFields | stringMap $dot.StringFuncs.quoteWrap | join ", "}}{{"}"}}, len(related))
+
+	_, err := bunny.Exec(ctx, sql, args...)
+	if err != nil {
+		return errors.Errorf("{{$dot.PkgName}}: failed to delete from join table: %w", err)
+	}
+
+	if o.R != nil {
+		o.R.{{$relationshipName}} = slices.DeleteFunc(o.R.{{$relationshipName}}, func(obj *{{$foreignModelName}}) bool {
+			return slices.ContainsFunc(related, func(rel *{{$foreignModelName}}) bool {
+				return {{$sameRelated}}
+			})
+		})
+	}
+	{{with $reverse -}}
+	for _, rel := range related {
+		if rel.R != nil {
+			rel.R.{{$reverse.Name | titleCase}} = slices.DeleteFunc(rel.R.{{$reverse.Name | titleCase}}, func(obj *{{$modelName}}) bool {
+				return {{$sameLocal}}
+			})
+		}
+	}
+	{{- end}}
+
+	return nil
+}
+
+{{- else if and (not .ToMany) ($model.HasForeignKey .LocalFields .ForeignModel) }}
+
+// Set{{$relationshipName}} sets the {{$relationshipName}} relationship of o to related, updating
+// the foreign key of o, and sets o.R.{{$relationshipName}}.
+// If insert is set, related is inserted first.
+func (o *{{$modelName}}) Set{{$relationshipName}}(ctx context.Context, insert bool, related *{{$foreignModelName}}) error {
+	if insert {
+		if err := related.Insert(ctx); err != nil {
+			return errors.Errorf("{{$dot.PkgName}}: failed to insert into foreign table: %w", err)
+		}
+	}
+
+	{{range $i, $lc := .LocalFields -}}
+	{{- $fc := index $rel.ForeignFields $i -}}
+	{{doAssign (printf "o.%s" ($lc | titleCasePath)) (printf "related.%s" ($fc | titleCasePath)) ($model.FindField $lc) ($foreignModel.FindField $fc)}}
+	{{end -}}
+	if err := o.Update(ctx{{range .LocalFields}}, "{{.SQLName}}"{{end}}); err != nil {
+		return errors.Errorf("{{$dot.PkgName}}: failed to update local table: %w", err)
+	}
+
+	if o.R == nil {
+		o.R = &{{$modelNameCamel}}R{}
+	}
+	{{with $reverse -}}
+	if prev := o.R.{{$relationshipName}}; prev != nil && prev != related && prev.R != nil {
+		{{if .ToMany -}}
+		prev.R.{{.Name | titleCase}} = slices.DeleteFunc(prev.R.{{.Name | titleCase}}, func(obj *{{$modelName}}) bool {
+			return obj == o
+		})
+		{{- else -}}
+		if prev.R.{{.Name | titleCase}} == o {
+			prev.R.{{.Name | titleCase}} = nil
+		}
+		{{- end}}
+	}
+	{{end -}}
+	o.R.{{$relationshipName}} = related
+	{{with $reverse -}}
+	if related.R == nil {
+		related.R = &{{$foreignModelNameCamel}}R{}
+	}
+	{{if .ToMany -}}
+	if !slices.Contains(related.R.{{.Name | titleCase}}, o) {
+		related.R.{{.Name | titleCase}} = append(related.R.{{.Name | titleCase}}, o)
+	}
+	{{- else -}}
+	related.R.{{.Name | titleCase}} = o
+	{{- end}}
+	{{- end}}
+
+	return nil
+}
+
+{{- else if $foreignModel.HasForeignKey .ForeignFields $model.Name }}
+{{- $nullable := true }}
+{{- range .ForeignFields }}{{ if not ($foreignModel.FindField .).Nullable }}{{ $nullable = false }}{{ end }}{{ end }}
+{{- if .ToMany }}
+
+// Add{{$relationshipName}} adds related to the {{$relationshipName}} relationship of o, updating
+// their foreign keys, and appends them to o.R.{{$relationshipName}}.
+// If insert is set, the related objects are inserted instead of updated.
+func (o *{{$modelName}}) Add{{$relationshipName}}(ctx context.Context, insert bool, related ...*{{$foreignModelName}}) error {
+	for _, rel := range related {
+		{{range $i, $fc := .ForeignFields -}}
+		{{- $lc := index $rel.LocalFields $i -}}
+		{{doAssign (printf "rel.%s" ($fc | titleCasePath)) (printf "o.%s" ($lc | titleCasePath)) ($foreignModel.FindField $fc) ($model.FindField $lc)}}
+		{{end -}}
+		if insert {
+			if err := rel.Insert(ctx); err != nil {
+				return errors.Errorf("{{$dot.PkgName}}: failed to insert into foreign table: %w", err)
+			}
+		} else {
+			if err := rel.Update(ctx{{range .ForeignFields}}, "{{.SQLName}}"{{end}}); err != nil {
+				return errors.Errorf("{{$dot.PkgName}}: failed to update foreign table: %w", err)
+			}
+		}
+	}
+
+	if o.R == nil {
+		o.R = &{{$modelNameCamel}}R{}
+	}
+	o.R.{{$relationshipName}} = append(o.R.{{$relationshipName}}, related...)
+	{{with $reverse -}}
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &{{$foreignModelNameCamel}}R{}
+		}
+		{{if .ToMany}}rel.R.{{.Name | titleCase}} = append(rel.R.{{.Name | titleCase}}, o){{else}}rel.R.{{.Name | titleCase}} = o{{end}}
+	}
+	{{- end}}
+
+	return nil
+}
+{{- if $nullable }}
+
+// Remove{{$relationshipName}} removes related from the {{$relationshipName}} relationship of o, setting
+// their foreign keys to null, and removes them from o.R.{{$relationshipName}}.
+func (o *{{$modelName}}) Remove{{$relationshipName}}(ctx context.Context, related ...*{{$foreignModelName}}) error {
+	for _, rel := range related {
+		{{range .ForeignFields -}}
+		rel.{{. | titleCasePath}} = {{goType ($foreignModel.FindField .).GoType}}{}
+		{{end -}}
+		if err := rel.Update(ctx{{range .ForeignFields}}, "{{.SQLName}}"{{end}}); err != nil {
+			return errors.Errorf("{{$dot.PkgName}}: failed to update foreign table: %w", err)
+		}
+		{{with $reverse -}}
+		if rel.R != nil {
+			rel.R.{{$reverse.Name | titleCase}} = nil
+		}
+		{{- end}}
+	}
+
+	if o.R != nil {
+		o.R.{{$relationshipName}} = slices.DeleteFunc(o.R.{{$relationshipName}}, func(obj *{{$foreignModelName}}) bool {
+			return slices.ContainsFunc(related, func(rel *{{$foreignModelName}}) bool {
+				return {{$sameRelated}}
+			})
+		})
+	}
+
+	return nil
+}
+{{- end}}
+{{- else }}
+
+// Set{{$relationshipName}} sets the {{$relationshipName}} relationship of o to related, updating
+// the foreign key of related, and sets o.R.{{$relationshipName}}.
+// If insert is set, related is inserted instead of updated.
+func (o *{{$modelName}}) Set{{$relationshipName}}(ctx context.Context, insert bool, related *{{$foreignModelName}}) error {
+	{{range $i, $fc := .ForeignFields -}}
+	{{- $lc := index $rel.LocalFields $i -}}
+	{{doAssign (printf "related.%s" ($fc | titleCasePath)) (printf "o.%s" ($lc | titleCasePath)) ($foreignModel.FindField $fc) ($model.FindField $lc)}}
+	{{end -}}
+	if insert {
+		if err := related.Insert(ctx); err != nil {
+			return errors.Errorf("{{$dot.PkgName}}: failed to insert into foreign table: %w", err)
+		}
+	} else {
+		if err := related.Update(ctx{{range .ForeignFields}}, "{{.SQLName}}"{{end}}); err != nil {
+			return errors.Errorf("{{$dot.PkgName}}: failed to update foreign table: %w", err)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &{{$modelNameCamel}}R{}
+	}
+	{{with $reverse -}}
+	if prev := o.R.{{$relationshipName}}; prev != nil && prev != related && prev.R != nil {
+		{{if .ToMany -}}
+		prev.R.{{.Name | titleCase}} = slices.DeleteFunc(prev.R.{{.Name | titleCase}}, func(obj *{{$modelName}}) bool {
+			return obj == o
+		})
+		{{- else -}}
+		if prev.R.{{.Name | titleCase}} == o {
+			prev.R.{{.Name | titleCase}} = nil
+		}
+		{{- end}}
+	}
+	{{end -}}
+	o.R.{{$relationshipName}} = related
+	{{with $reverse -}}
+	if related.R == nil {
+		related.R = &{{$foreignModelNameCamel}}R{}
+	}
+	{{if .ToMany -}}
+	if !slices.Contains(related.R.{{.Name | titleCase}}, o) {
+		related.R.{{.Name | titleCase}} = append(related.R.{{.Name | titleCase}}, o)
+	}
+	{{- else -}}
+	related.R.{{.Name | titleCase}} = o
+	{{- end}}
+	{{- end}}
+
+	return nil
+}
+{{- end}}
+{{- end}}
+
+{{ end -}}
@@ -0,0 +1,152 @@
+{{- $dot := . -}}
+{{- $model := .Model -}}
+{{- $modelName := .Model.Name | titleCase -}}
+{{- $modelNameCamel := .Model.Name | camelCase -}}
+{{- $schemaModel := .Model.Name | schemaModel -}}
+
+{{ range .Model.Relationships -}}
+
+{{- $rel := . }}
+{{- $relationshipName := .Name | titleCase}}
+{{- $foreignModel := index $dot.Schema.Models .ForeignModel }}
+{{- $foreignSchemaModel := .ForeignModel | schemaModel }}
+{{- $foreignTable := .ForeignModel }}
+{{- $foreignRef := $foreignSchemaModel }}
+{{- if eq .ForeignModel $model.Name }}{{ $foreignTable = "e" }}{{ $foreignRef = "e" }}{{ end }}
+{{- $outerTable := $foreignTable }}
+{{- $outerFields := .ForeignFields }}
+{{- if .IsJoinModel }}{{ $outerTable = .JoinModel }}{{ $outerFields = .JoinLocalFields }}{{ end }}
+{{- $outerOn := "" }}
+{{- range $i, $p := .LocalFields }}{{ if $i }}{{ $outerOn = printf "%s AND " $outerOn }}{{ end }}{{ $outerOn = printf "%s%s.%s=%%[1]s.%s" $outerOn (quotes $outerTable) (quotes (index $outerFields $i).SQLName) (quotes $p.SQLName) }}{{ end }}
+
+// {{$modelName}}WhereHas{{$relationshipName}} matches the {{$modelName}} rows with {{$relationshipName}} matching mods.
+func {{$modelName}}WhereHas{{$relationshipName}}(mods ...qm.QueryMod) qm.QueryMod {
+	return qm.WhereExists({{$modelNameCamel}}{{$relationshipName}}Exists("{{$schemaModel}}", mods))
+}
+
+// {{$modelName}}WhereHas{{$relationshipName}}As is {{$modelName}}WhereHas{{$relationshipName}} for queries with
+// the {{$modelName}} table aliased as alias, like the f alias in the loaders of qm.LoadWith.
+// alias is used as is, it's not quoted.
+func {{$modelName}}WhereHas{{$relationshipName}}As(alias string, mods ...qm.QueryMod) qm.QueryMod {
+	return qm.WhereExists({{$modelNameCamel}}{{$relationshipName}}Exists(alias, mods))
+}
+
+// {{$modelName}}WhereDoesntHave{{$relationshipName}} matches the {{$modelName}} rows without {{$relationshipName}} matching mods.
+func {{$modelName}}WhereDoesntHave{{$relationshipName}}(mods ...qm.QueryMod) qm.QueryMod {
+	return qm.WhereNotExists({{$modelNameCamel}}{{$relationshipName}}Exists("{{$schemaModel}}", mods))
+}
+
+// {{$modelName}}WhereDoesntHave{{$relationshipName}}As is {{$modelName}}WhereDoesntHave{{$relationshipName}} for queries
+// with the {{$modelName}} table aliased as alias. alias is used as is, it's not quoted.
+func {{$modelName}}WhereDoesntHave{{$relationshipName}}As(alias string, mods ...qm.QueryMod) qm.QueryMod {
+	return qm.WhereNotExists({{$modelNameCamel}}{{$relationshipName}}Exists(alias, mods))
+}
+
+// {{$modelNameCamel}}{{$relationshipName}}Exists returns the query selecting the {{$relationshipName}}
+// of the {{$modelName}} row referenced as outer.
+func {{$modelNameCamel}}{{$relationshipName}}Exists(outer string, mods []qm.QueryMod) *queries.Query {
+	queryMods := []qm.QueryMod{
+		qm.Select("1"),
+		{{if eq $foreignTable "e" -}}
+		qm.From("{{$foreignSchemaModel}} AS e"),
+		{{- else -}}
+		qm.From("{{$foreignSchemaModel}}"),
+		{{- end}}
+		{{- if .IsJoinModel}}
+		qm.InnerJoin("{{.JoinModel | schemaModel}} ON {{joinOnClause $dot.LQ $dot.RQ .JoinModel .JoinForeignFields $foreignTable .ForeignFields}}"),
+		{{- end}}
+		qm.Where(fmt.Sprintf("{{$outerOn}}", outer)),
+		{{if .ForeignWhere -}}
+		qm.Where("{{replaceAll .ForeignWhere "$foreign" $foreignRef}}"),
+		{{- end}}
+	}
+
+	return NewQuery(append(queryMods, mods...)...)
+}
+
+{{- if .ToMany }}
+{{- $keyModel := $foreignModel }}
+{{- $keyFields := .ForeignFields }}
+{{- $keyTable := "f" }}
+{{- if .IsJoinModel }}
+{{- $keyModel = index $dot.Schema.Models .JoinModel }}
+{{- $keyFields = .JoinLocalFields }}
+{{- $keyTable = "j" }}
+{{- end }}
+{{- $keyColumns := "" }}
+{{- range $i, $p := $keyFields }}{{ if $i }}{{ $keyColumns = printf "%s, " $keyColumns }}{{ end }}{{ $keyColumns = printf "%s%s.%s" $keyColumns (quotes $keyTable) (quotes $p.SQLName) }}{{ end }}
+
+// Load{{$relationshipName}}Count allows an eager lookup of the number of {{$relationshipName}},
+// cached into the loaded structs of the objects. mods are applied to the query counting them.
+func ({{$modelNameCamel}}L) Load{{$relationshipName}}Count(ctx context.Context, slice []*{{$modelName}}, mods ...qm.QueryMod) error {
+	locals := make(map[interface{}][]*{{$modelName}})
+	var args []interface{}
+	for _, obj := range slice {
+		if obj.R == nil {
+			obj.R = &{{$modelNameCamel}}R{}
+		}
+		obj.R.{{$relationshipName}}Count = 0
+		{{ range .LocalFields -}}
+		{{- if ($model.FindField .).Nullable }}
+		if !obj.{{. | titleCasePath}}.Valid {
+			continue
+		}
+		{{- end}}
+		{{- end }}
+		key := {{loadKey "obj" .LocalFields $model}}
+		if _, ok := locals[key]; !ok {
+			args = append(args{{range .LocalFields}}, obj.{{. | titleCasePath}}{{end}})
+		}
+		locals[key] = append(locals[key], obj)
+	}
+
+	for _, chunk := range queries.LoadChunks(args, {{len .LocalFields}}) {
+
+		where := fmt.Sprintf(
+			"{{ whereInClause $dot.LQ $dot.RQ $keyTable $keyFields }} in (%s)",
+			strmangle.Placeholders(false, len(chunk), 1, {{len .LocalFields}}),
+		)
+		queryMods := []qm.QueryMod{
+			qm.Select("{{$keyColumns}}", "count(*)"),
+			qm.From("{{$foreignSchemaModel}} AS f"),
+			{{if .IsJoinModel -}}
+			qm.InnerJoin("{{.JoinModel | schemaModel }} AS j ON {{joinOnClause $dot.LQ $dot.RQ "j" .JoinForeignFields "f" .ForeignFields}}"),
+			{{- end}}
+			qm.Where(where, chunk...),
+			{{if .ForeignWhere -}}
+			qm.Where("{{replaceAll .ForeignWhere "$foreign" "f"}}"),
+			{{- end}}
+			qm.GroupBy("{{$keyColumns}}"),
+		}
+		query := NewQuery(append(queryMods, mods...)...)
+
+		rows, err := query.Query(ctx)
+		if err != nil {
+			return errors.Errorf("failed to count eager loaded {{$relationshipName}}: %w", err)
+		}
+		for rows.Next() {
+			{{range $i, $p := $keyFields -}}
+			var k{{$i}} {{goType ($keyModel.FindField $p).GoType}}
+			{{end -}}
+			var count int64
+			if err := rows.Scan({{range $i, $p := $keyFields}}&k{{$i}}, {{end}}&count); err != nil {
+				rows.Close()
+				return errors.Errorf("failed to scan eager loaded {{$relationshipName}} count: %w", err)
+			}
+			for _, local := range locals[{{scanKey $keyFields $keyModel}}] {
+				local.R.{{$relationshipName}}Count = count
+			}
+		}
+		if err := rows.Close(); err != nil {
+			return errors.Errorf("failed to count eager loaded {{$relationshipName}}: %w", err)
+		}
+		if err := rows.Err(); err != nil {
+			return errors.Errorf("failed to count eager loaded {{$relationshipName}}: %w", err)
+		}
+	}
+
+	return nil
+}
+{{- end }}
+
+{{ end -}}
@@ -6,3 +6,11 @@ func {{$modelNamePlural}}(mods ...qm.QueryMod) {{$varNameSingular}}Query {
 	mods = append(mods, qm.From("{{.Model.Name | schemaModel}}"))
 	return {{$varNameSingular}}Query{NewQuery(mods...)}
 }
+
+// Clone returns a copy of the query with the given mods applied, leaving q unchanged.
+// Use it to build several queries from a shared base query.
+func (q {{$varNameSingular}}Query) Clone(mods ...qm.QueryMod) {{$varNameSingular}}Query {
+	query := q.Query.Clone()
+	qm.Apply(query, mods...)
+	return {{$varNameSingular}}Query{query}
+}
@@ -74,3 +74,78 @@ func (o *{{$modelNameSingular}}) InsertIgnore(ctx context.Context, ignoreConflic
 
 	return inserted, nil
 }
+
+// InsertAll inserts all the rows in the slice, running the insert hooks of each one.
+// From bunny.CopyInThreshold rows, if the database supports it, rows are inserted with
+// COPY FROM STDIN. Otherwise they're inserted with multi-row INSERT statements.
+// Use bunny.Atomic to insert either all the rows or none.
+// whitelist selects the inserted columns, as in Insert.
+func (o {{$modelNameSingular}}Slice) InsertAll(ctx context.Context, whitelist ...string) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	var err error
+
+	{{with hook . "before_insert" "obj" .Model}}
+	for _, obj := range o {
+		{{.}}
+	}
+	{{- end}}
+
+	if len(whitelist) == 0 {
+		whitelist = {{$varNameSingular}}Columns
+	}
+
+	valueMapping, err := queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, whitelist)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]interface{}, len(o))
+	for i, obj := range o {
+		rows[i] = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), valueMapping)
+	}
+
+	if len(rows) >= bunny.CopyInThreshold && bunny.CanCopyIn(ctx) {
+		err = bunny.CopyIn(ctx, "{{.Model.Name}}", whitelist, {{$varNameSingular}}ByteaColumns, rows)
+		if err != nil {
+			return errors.Errorf("{{.PkgName}}: unable to copy into {{.Model.Name}}: %w", err)
+		}
+	} else {
+		// Postgres allows at most 65535 parameters per statement.
+		chunkSize := 65535 / len(whitelist)
+		var query string
+		var queryRows int
+		for start := 0; start < len(rows); start += chunkSize {
+			chunk := rows[start:min(start+chunkSize, len(rows))]
+
+			if len(chunk) != queryRows {
+				values := make([]string, len(chunk))
+				for i := range chunk {
+					values[i] = "(" + strmangle.Placeholders(dialect.IndexPlaceholders, len(whitelist), i*len(whitelist)+1, 1) + ")"
+				}
+				query = fmt.Sprintf("INSERT INTO {{$schemaModel}} ({{.LQ}}%s{{.RQ}}) VALUES %s", strings.Join(whitelist, "{{.RQ}},{{.LQ}}"), strings.Join(values, ","))
+				queryRows = len(chunk)
+			}
+
+			args := make([]interface{}, 0, len(chunk)*len(whitelist))
+			for _, row := range chunk {
+				args = append(args, row...)
+			}
+
+			_, err = bunny.Exec(ctx, query, args...)
+			if err != nil {
+				return errors.Errorf("{{.PkgName}}: unable to insert all into {{.Model.Name}}: %w", err)
+			}
+		}
+	}
+
+	{{with hook . "after_insert" "obj" .Model}}
+	for _, obj := range o {
+		{{.}}
+	}
+	{{- end}}
+
+	return nil
+}
@@ -56,11 +56,80 @@ func (o *{{$modelNameSingular}}) Update(ctx context.Context, whitelist ... strin
 	return nil
 }
 
+// UpdateAll updates all the rows in the slice, each one with its own values, in a single
+// statement per 65535 parameters. Whitelist behavior is the same as in Update.
+func (o {{$modelNameSingular}}Slice) UpdateAll(ctx context.Context, whitelist ...string) error {
+	var err error
+
+	if len(o) == 0 {
+		return nil
+	}
+
+	{{with hook . "before_update" "obj" .Model}}
+	for _, obj := range o {
+		{{.}}
+	}
+	{{end}}
+
+	if len(whitelist) == 0 {
+		whitelist = {{$varNameSingular}}NonPrimaryKeyColumns
+	}
+
+	if len(whitelist) == 0 {
+		// Nothing to update
+		return nil
+	}
+
+	key := makeCacheKey(whitelist)
+	{{$varNameSingular}}UpdateAllCacheMut.RLock()
+	cache, cached := {{$varNameSingular}}UpdateAllCache[key]
+	{{$varNameSingular}}UpdateAllCacheMut.RUnlock()
+
+	if !cached {
+		cache.valueMapping, err = queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, append(append([]string{}, whitelist...), {{$varNameSingular}}PrimaryKeyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Postgres allows at most 65535 parameters per statement.
+	chunkSize := 65535 / len(cache.valueMapping)
+	for start := 0; start < len(o); start += chunkSize {
+		chunk := o[start:min(start+chunkSize, len(o))]
+
+		sql := queries.BuildUpdateAllQueryPostgres(dialect, "{{$schemaModel}}", whitelist, {{$varNameSingular}}PrimaryKeyColumns, {{$varNameSingular}}ColumnTypes, len(chunk))
+		args := make([]interface{}, 0, len(chunk)*len(cache.valueMapping))
+		for _, obj := range chunk {
+			args = append(args, queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), cache.valueMapping)...)
+		}
+
+		_, err = bunny.Exec(ctx, sql, args...)
+		if err != nil {
+			return errors.Errorf("{{.PkgName}}: unable to update all in {{$varNameSingular}} slice: %w", err)
+		}
+	}
+
+	if !cached {
+		{{$varNameSingular}}UpdateAllCacheMut.Lock()
+		{{$varNameSingular}}UpdateAllCache[key] = cache
+		{{$varNameSingular}}UpdateAllCacheMut.Unlock()
+	}
+
+	{{with hook . "after_update" "obj" .Model}}
+	for _, obj := range o {
+		{{.}}
+	}
+	{{end}}
+
+	return nil
+}
+
 // UpdateMapAll updates all rows with the specified field values.
 func (q {{$varNameSingular}}Query) UpdateMapAll(ctx context.Context, cols M) error {
-	queries.SetUpdate(q.Query, cols)
+	query := q.Query.Clone()
+	queries.SetUpdate(query, cols)
 
-	_, err := q.Query.Exec(ctx)
+	_, err := query.Exec(ctx)
 	if err != nil {
 		return errors.Errorf("{{.PkgName}}: unable to update all for {{.Model.Name}}: %w", err)
 	}
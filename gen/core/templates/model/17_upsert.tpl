@@ -0,0 +1,86 @@
+{{- $modelNameSingular := .Model.Name | singular | titleCase -}}
+{{- $varNameSingular := .Model.Name | singular | camelCase -}}
+{{- $schemaModel := .Model.Name | schemaModel}}
+// Upsert inserts the {{$modelNameSingular}}, or updates the existing row if the insert conflicts
+// with it on conflictColumns. The resulting row is read back into o.
+// conflictColumns defaults to the primary key.
+// updateColumns defaults to the inserted columns not in conflictColumns. Its entries can be column
+// names, set to the inserted value, or assignments like `count = {{.Model.Name}}.count + EXCLUDED.count`.
+// If updateColumns is empty but not nil, conflicting rows are left as is and o is not modified.
+// whitelist selects the inserted columns, as in Insert.
+func (o *{{$modelNameSingular}}) Upsert(ctx context.Context, conflictColumns, updateColumns []string, whitelist ...string) error {
+	return o.UpsertWhere(ctx, conflictColumns, updateColumns, "", whitelist...)
+}
+
+// UpsertWhere is like Upsert, but the conflicting row is only updated if it matches the where
+// condition, like `{{.Model.Name}}.updated_at < EXCLUDED.updated_at`. If it doesn't, o is not modified.
+// The after insert hooks only run if the row is inserted or updated.
+func (o *{{$modelNameSingular}}) UpsertWhere(ctx context.Context, conflictColumns, updateColumns []string, where string, whitelist ...string) error {
+	if o == nil {
+		return errors.New("{{.PkgName}}: no {{.Model.Name}} provided for upsert")
+	}
+
+	var err error
+
+	{{ hook . "before_insert" "o" .Model }}
+
+	if len(whitelist) == 0 {
+		whitelist = {{$varNameSingular}}Columns
+	}
+	if len(conflictColumns) == 0 {
+		conflictColumns = {{$varNameSingular}}PrimaryKeyColumns
+	}
+	if updateColumns == nil {
+		updateColumns = strmangle.SetComplement(whitelist, conflictColumns)
+	}
+
+	key := makeCacheKey(conflictColumns) + "\x00" + makeCacheKey(updateColumns) + "\x00" + where + "\x00" + makeCacheKey(whitelist)
+	{{$varNameSingular}}UpsertCacheMut.RLock()
+	cache, cached := {{$varNameSingular}}UpsertCache[key]
+	{{$varNameSingular}}UpsertCacheMut.RUnlock()
+
+	if !cached {
+		cache.valueMapping, err = queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, whitelist)
+		if err != nil {
+			return err
+		}
+		cache.returnMapping, err = queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, {{$varNameSingular}}Columns)
+		if err != nil {
+			return err
+		}
+
+		var columns, assignments []string
+		for _, c := range updateColumns {
+			if strings.Contains(c, "=") {
+				assignments = append(assignments, c)
+			} else {
+				columns = append(columns, c)
+			}
+		}
+		cache.query = queries.BuildUpsertQueryPostgresWhere(dialect, "{{$schemaModel}}", len(updateColumns) != 0, {{$varNameSingular}}Columns, columns, assignments, conflictColumns, whitelist, where)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	err = bunny.QueryRow(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.returnMapping)...)
+	// No rows are returned if the row conflicted and was not updated.
+	untouched := bunny.IsErrNoRows(err)
+	if err != nil && !untouched {
+		return errors.Errorf("{{.PkgName}}: unable to upsert {{.Model.Name}}: %w", err)
+	}
+
+	if !cached {
+		{{$varNameSingular}}UpsertCacheMut.Lock()
+		{{$varNameSingular}}UpsertCache[key] = cache
+		{{$varNameSingular}}UpsertCacheMut.Unlock()
+	}
+
+	if untouched {
+		return nil
+	}
+
+	{{ hook . "after_insert" "o" .Model }}
+
+	return nil
+}
@@ -29,9 +29,10 @@ func (q {{$varNameSingular}}Query) DeleteAll(ctx context.Context) error {
 	return errors.New("{{.PkgName}}: no {{$varNameSingular}}Query provided for delete all")
 	}
 
-	queries.SetDelete(q.Query)
+	query := q.Query.Clone()
+	queries.SetDelete(query)
 
-	_, err := q.Query.Exec(ctx)
+	_, err := query.Exec(ctx)
 	if err != nil {
 	return errors.Errorf("{{.PkgName}}: unable to delete all from {{.Model.Name}}: %w", err)
 	}
@@ -0,0 +1,66 @@
+{{- $dot := . -}}
+{{- $modelNameSingular := .Model.Name | singular | titleCase -}}
+{{- $varNameSingular := .Model.Name | singular | camelCase -}}
+var (
+	{{$varNameSingular}}PaginationColumns = []string{{"{"}}{{modelPaginationColumns .Model | stringMap .StringFuncs.quoteWrap | join ", "}}{{"}"}}
+	{{$varNameSingular}}PaginationKey     = []string{{"{"}}{{range $i, $column := modelPaginationColumns .Model}}{{if $i}}, {{end}}"{{quotes $dot.Model.Name}}.{{quotes $column}}"{{end}}{{"}"}}
+	{{$varNameSingular}}PaginationMapping, _ = queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, {{$varNameSingular}}PaginationColumns)
+)
+
+// Paginate returns a page of at most limit {{$modelNameSingular}} records from the query, using keyset
+// pagination on {{modelPaginationColumns .Model | join ", "}}, and the cursors of the pages around it.
+// An empty cursor returns the first page. limit must be positive. The ordering of the
+// query is replaced, and Limit and Offset must not be used.
+func (q {{$varNameSingular}}Query) Paginate(ctx context.Context, cursor string, limit int) ({{$modelNameSingular}}Slice, *queries.Page, error) {
+	if limit <= 0 {
+		return nil, nil, errors.Errorf("{{.PkgName}}: failed to paginate {{.Model.Name}}: invalid limit %d", limit)
+	}
+
+	var keyValues []interface{}
+	backward := false
+	if cursor != "" {
+		key := reflect.ValueOf(&{{$modelNameSingular}}{}).Elem()
+		var err error
+		backward, err = queries.DecodeCursor(cursor, queries.PtrsFromMapping(key, {{$varNameSingular}}PaginationMapping)...)
+		if err != nil {
+			return nil, nil, errors.Errorf("{{.PkgName}}: failed to paginate {{.Model.Name}}: %w", err)
+		}
+		keyValues = queries.ValuesFromMapping(key, {{$varNameSingular}}PaginationMapping)
+	}
+
+	query := q.Clone()
+	queries.SetKeyset(query.Query, {{$varNameSingular}}PaginationKey, keyValues, backward)
+	// Fetch one more row to know if there are more pages.
+	queries.SetLimit(query.Query, limit+1)
+
+	o, err := query.All(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	more := len(o) > limit
+	if more {
+		o = o[:limit]
+	}
+	if len(o) == 0 {
+		return o, &queries.Page{}, nil
+	}
+	if backward {
+		for i, j := 0, len(o)-1; i < j; i, j = i+1, j-1 {
+			o[i], o[j] = o[j], o[i]
+		}
+	}
+
+	first := queries.ValuesFromMapping(reflect.ValueOf(o[0]).Elem(), {{$varNameSingular}}PaginationMapping)
+	last := queries.ValuesFromMapping(reflect.ValueOf(o[len(o)-1]).Elem(), {{$varNameSingular}}PaginationMapping)
+	hasPrev, hasNext := cursor != "", more
+	if backward {
+		hasPrev, hasNext = more, true
+	}
+	page, err := queries.NewPage(first, last, hasPrev, hasNext)
+	if err != nil {
+		return nil, nil, errors.Errorf("{{.PkgName}}: failed to paginate {{.Model.Name}}: %w", err)
+	}
+
+	return o, page, nil
+}
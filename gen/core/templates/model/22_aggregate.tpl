@@ -0,0 +1,52 @@
+{{- $dot := . -}}
+{{- $modelNameSingular := .Model.Name | singular | titleCase -}}
+{{- $varNameSingular := .Model.Name | singular | camelCase -}}
+{{- if modelAggregateColumns .Model -}}
+func (q {{$varNameSingular}}Query) aggregate(ctx context.Context, fn, column string, res interface{}) error {
+	query := q.Query.Clone()
+	queries.SetSelect(query, []string{fn + "(" + column + ")"})
+
+	err := query.QueryRow(ctx).Scan(res)
+	if err != nil {
+		return errors.Errorf("{{.PkgName}}: failed to get {{.Model.Name}} %s: %w", fn, err)
+	}
+
+	return nil
+}
+{{- end}}
+{{range $column := modelAggregateColumns .Model -}}
+{{- $name := $column.Name | titleCase -}}
+{{- $sqlColumn := printf "%s.%s" (quotes $dot.Model.Name) (quotes $column.Name) -}}
+{{- if $column.SumGoType.Name}}
+// Sum{{$name}} returns the sum of the {{$column.Name}} column of the {{$modelNameSingular}} records
+// from the query, or null if there are none.
+func (q {{$varNameSingular}}Query) Sum{{$name}}(ctx context.Context) ({{goType $column.SumGoType}}, error) {
+	var res {{goType $column.SumGoType}}
+	err := q.aggregate(ctx, "sum", "{{$sqlColumn}}", &res)
+	return res, err
+}
+
+// Avg{{$name}} returns the average of the {{$column.Name}} column of the {{$modelNameSingular}} records
+// from the query, or null if there are none.
+func (q {{$varNameSingular}}Query) Avg{{$name}}(ctx context.Context) ({{goType $column.AvgGoType}}, error) {
+	var res {{goType $column.AvgGoType}}
+	err := q.aggregate(ctx, "avg", "{{$sqlColumn}}", &res)
+	return res, err
+}
+{{end}}
+// Min{{$name}} returns the minimum of the {{$column.Name}} column of the {{$modelNameSingular}} records
+// from the query, or null if there are none.
+func (q {{$varNameSingular}}Query) Min{{$name}}(ctx context.Context) ({{goType $column.MinMaxGoType}}, error) {
+	var res {{goType $column.MinMaxGoType}}
+	err := q.aggregate(ctx, "min", "{{$sqlColumn}}", &res)
+	return res, err
+}
+
+// Max{{$name}} returns the maximum of the {{$column.Name}} column of the {{$modelNameSingular}} records
+// from the query, or null if there are none.
+func (q {{$varNameSingular}}Query) Max{{$name}}(ctx context.Context) ({{goType $column.MinMaxGoType}}, error) {
+	var res {{goType $column.MinMaxGoType}}
+	err := q.aggregate(ctx, "max", "{{$sqlColumn}}", &res)
+	return res, err
+}
+{{end -}}
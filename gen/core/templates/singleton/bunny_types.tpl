@@ -4,7 +4,10 @@ import (
 	"github.com/sqlbunny/sqlbunny/runtime/queries"
 )
 
-// M type is for providing fields and field values to UpdateAll.
+// errStopIteration stops the iteration of the Each methods when an iterator is stopped early.
+var errStopIteration = errors.New("stop iteration")
+
+// M type is for providing fields and field values to UpdateMapAll.
 type M map[string]interface{}
 
 type insertCache struct {
@@ -17,6 +20,12 @@ type updateCache struct {
 	valueMapping []queries.MappedField
 }
 
+type upsertCache struct {
+	query         string
+	valueMapping  []queries.MappedField
+	returnMapping []queries.MappedField
+}
+
 func makeCacheKey(wl []string) string {
 	buf := strmangle.GetBuffer()
 
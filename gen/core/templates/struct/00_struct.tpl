@@ -4,6 +4,7 @@ import (
     "database/sql/driver"
     "encoding/json"
 
+    "github.com/sqlbunny/errors"
     "github.com/sqlbunny/sqlbunny/runtime/bunny"
     "github.com/sqlbunny/sqlbunny/types/null/convert"
 )
@@ -18,3 +19,22 @@ type {{$modelName}} struct {
 	{{titleCase $field.Name}} {{goType $field.GoType}} `{{$field.GenerateTags}}`
 	{{- end -}}
 }
+
+{{- if .Schema.IsStoredAsJSON .Struct }}
+
+// Scan implements the Scanner interface. It's used when the struct is stored in a JSON column.
+func (o *{{$modelName}}) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, o)
+	case string:
+		return json.Unmarshal([]byte(v), o)
+	}
+	return errors.Errorf("{{.PkgName}}: cannot scan type %T into {{$modelName}}", value)
+}
+
+// Value implements the driver Valuer interface. It's used when the struct is stored in a JSON column.
+func (o {{$modelName}}) Value() (driver.Value, error) {
+	return json.Marshal(o)
+}
+{{- end }}
@@ -58,6 +58,28 @@ func (u Null{{$modelName}}) Ptr() *{{$modelName}} {
 	return &u.{{$modelName}}
 }
 
+{{- if .Schema.IsStoredAsJSON .Struct }}
+
+// Scan implements the Scanner interface. It's used when the struct is stored in a JSON column.
+func (u *Null{{$modelName}}) Scan(value interface{}) error {
+	if value == nil {
+		u.{{$modelName}}, u.Valid = {{$modelName}}{}, false
+		return nil
+	}
+	err := u.{{$modelName}}.Scan(value)
+	u.Valid = err == nil
+	return err
+}
+
+// Value implements the driver Valuer interface. It's used when the struct is stored in a JSON column.
+func (u Null{{$modelName}}) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.{{$modelName}}.Value()
+}
+{{- end }}
+
 func (u Null{{$modelName}}) IsZero() bool {
 	return !u.Valid
 }
@@ -0,0 +1,177 @@
+package core
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sqlbunny/sqlbunny/gen"
+	"github.com/sqlbunny/sqlbunny/runtime/queries"
+	"github.com/sqlbunny/sqlbunny/schema"
+)
+
+// testItems is the schema the model templates are tested with.
+func testItems() []gen.ConfigItem {
+	return []gen.ConfigItem{
+		Type("string", BaseType{
+			Go:       "string",
+			GoNull:   "github.com/sqlbunny/sqlbunny/types/null.String",
+			Postgres: SQLType{Type: "text", ZeroValue: "''"},
+		}),
+		Model("user",
+			Field("id", "string", PrimaryKey),
+			Field("parent_id", "string", Null, ForeignKey("user")),
+		),
+		Model("book",
+			Field("id", "string", PrimaryKey),
+			Field("status", "string"),
+			Field("author_id", "string", ForeignKey("user")),
+		),
+		Model("tag",
+			Field("id", "string", PrimaryKey),
+		),
+		Model("book_tag",
+			Field("book_id", "string", ForeignKey("book")),
+			Field("tag_id", "string", ForeignKey("tag")),
+			PrimaryKey("book_id", "tag_id"),
+		),
+		Model("edition",
+			Field("book_id", "string"),
+			Field("code", "string"),
+			PrimaryKey("book_id", "code"),
+		),
+		Model("copy",
+			Field("id", "string", PrimaryKey),
+			Field("edition_book_id", "string"),
+			Field("edition_code", "string", Null),
+			ModelForeignKey("edition", "edition_book_id", "edition_code"),
+		),
+	}
+}
+
+// genModel returns the code generated by the model templates for the model, after
+// applying edits to the schema. It sets the global gen.Config, so the tests using it
+// can't run in parallel.
+func genModel(t *testing.T, model string, edits ...func(s *schema.Schema)) string {
+	t.Helper()
+
+	items := testItems()
+	gen.Config = &gen.ConfigStruct{
+		Items:             items,
+		Dialect:           queries.Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+		ModelsPackagePath: t.TempDir(),
+		ModelsPackageName: "models",
+	}
+	s, err := buildSchema(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, edit := range edits {
+		edit(s)
+	}
+	gen.Config.Schema = s
+
+	tpl, err := gen.LoadTemplates(templatesPackage, templatesModelDirectory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := gen.BaseTemplateData()
+	data["Model"] = s.Models[model]
+	tpl.Execute(data, model+".gen.go")
+
+	out, err := ioutil.ReadFile(filepath.Join(gen.Config.ModelsPackagePath, model+".gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func checkGenerated(t *testing.T, out string, want ...string) {
+	t.Helper()
+
+	for _, w := range want {
+		if !strings.Contains(out, w) {
+			t.Errorf("generated code doesn't contain:\n%s", w)
+		}
+	}
+}
+
+func TestWhereHas(t *testing.T) {
+	out := genModel(t, "book")
+	checkGenerated(t, out,
+		`return qm.WhereExists(bookAuthorExists("\"book\"", mods))`,
+		`return qm.WhereNotExists(bookAuthorExists("\"book\"", mods))`,
+		`return qm.WhereExists(bookAuthorExists(alias, mods))`,
+		`qm.From("\"user\""),
+		qm.Where(fmt.Sprintf("\"user\".\"id\"=%[1]s.\"author_id\"", outer)),`,
+	)
+}
+
+func TestWhereHasSelf(t *testing.T) {
+	out := genModel(t, "user")
+	checkGenerated(t, out,
+		// The related table is aliased, so the outer one can be too, even as f.
+		`qm.From("\"user\" AS e"),
+		qm.Where(fmt.Sprintf("\"e\".\"id\"=%[1]s.\"parent_id\"", outer)),`,
+		`qm.From("\"user\" AS e"),
+		qm.Where(fmt.Sprintf("\"e\".\"parent_id\"=%[1]s.\"id\"", outer)),`,
+	)
+}
+
+func TestWhereHasJoinModel(t *testing.T) {
+	out := genModel(t, "book")
+	checkGenerated(t, out,
+		`qm.From("\"tag\""),
+		qm.InnerJoin("\"book_tag\" ON \"book_tag\".\"tag_id\"=\"tag\".\"id\""),
+		qm.Where(fmt.Sprintf("\"book_tag\".\"book_id\"=%[1]s.\"id\"", outer)),`,
+	)
+}
+
+func TestLoadJoinModelForeignWhere(t *testing.T) {
+	out := genModel(t, "book", func(s *schema.Schema) {
+		for _, r := range s.Models["book"].Relationships {
+			if r.IsJoinModel {
+				r.ForeignWhere = "$foreign.deleted IS NULL AND $foreign.flag"
+			}
+		}
+	})
+	checkGenerated(t, out,
+		`qm.From("\"tag\" AS f"),
+			qm.InnerJoin("\"book_tag\" AS j ON \"j\".\"tag_id\"=\"f\".\"id\""),
+			qm.Where(where, chunk...),
+			qm.Where("f.deleted IS NULL AND f.flag"),`,
+	)
+}
+
+func TestLoadCompositeKey(t *testing.T) {
+	// The keys of the loaded objects must match the ones of the related objects,
+	// with the values of nullable fields.
+	out := genModel(t, "copy")
+	checkGenerated(t, out,
+		`key := [2]interface{}{obj.EditionBookID, obj.EditionCode.String}`,
+		`for _, chunk := range queries.LoadChunks(args, 2) {`,
+		`for _, local := range locals[[2]interface{}{foreign.BookID, foreign.Code}] {`,
+	)
+
+	out = genModel(t, "edition")
+	checkGenerated(t, out,
+		`key := [2]interface{}{obj.BookID, obj.Code}`,
+		`for _, local := range locals[[2]interface{}{foreign.EditionBookID, foreign.EditionCode.String}] {`,
+	)
+}
+
+func TestWhereHelpers(t *testing.T) {
+	out := genModel(t, "book")
+	checkGenerated(t, out,
+		`qm.NewStringWhereHelper[string]("\"book\"", "\"status\""),`,
+		`qm.NewOrderByHelper("\"book\"", "\"status\""),`,
+	)
+}
+
+func TestUpsertAssignments(t *testing.T) {
+	out := genModel(t, "book")
+	checkGenerated(t, out,
+		`queries.BuildUpsertQueryPostgresWhere(dialect, "\"book\"", len(updateColumns) != 0, bookColumns, columns, assignments, conflictColumns, whitelist, where)`,
+	)
+}
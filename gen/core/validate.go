@@ -21,6 +21,7 @@ func buildSchema(items []gen.ConfigItem) (*schema.Schema, error) {
 	for _, m := range ctx.Schema.Models {
 		checkDuplicateFields(ctx, m)
 		checkPrimaryKey(ctx, m)
+		checkPaginateBy(ctx, m)
 		checkIndexes(ctx, m)
 		checkUniques(ctx, m)
 		checkForeignKeys(ctx, m)
@@ -103,7 +104,7 @@ func checkDuplicateFields(ctx *gen.Context, m *schema.Model) {
 	seen := make(map[string]struct{})
 	for _, f := range m.Fields {
 		if _, ok := seen[f.Name]; ok {
-			ctx.AddError("Model '%s' field '%s' is defined multiple times.", m.Name, f.Name)
+			ctx.AddError("Model '%s' field '%s'%s is defined multiple times.", m.Name, f.Name, fromMixin(&f.Extendable))
 		}
 		seen[f.Name] = struct{}{}
 	}
@@ -119,30 +120,50 @@ func checkPrimaryKey(ctx *gen.Context, m *schema.Model) {
 		return
 	}
 
+	from := fromMixin(&m.PrimaryKey.Extendable)
 	for _, p := range m.PrimaryKey.Fields {
 		f := m.FindField(p)
 		if f == nil {
-			ctx.AddError("Model '%s' primary key references unknown field '%s'", m.Name, p.DotName())
+			ctx.AddError("Model '%s' primary key%s references unknown field '%s'", m.Name, from, p.DotName())
 		} else if f.Nullable {
-			ctx.AddError("Model '%s' primary key references nullable field '%s'", m.Name, p.DotName())
+			ctx.AddError("Model '%s' primary key%s references nullable field '%s'", m.Name, from, p.DotName())
 		}
 	}
 }
 
+func checkPaginateBy(ctx *gen.Context, m *schema.Model) {
+	if m.PaginateBy == nil {
+		return
+	}
+
+	for _, p := range m.PaginateBy {
+		f := m.FindField(p)
+		if f == nil {
+			ctx.AddError("Model '%s' pagination key references unknown field '%s'", m.Name, p.DotName())
+		} else if f.Nullable {
+			ctx.AddError("Model '%s' pagination key references nullable field '%s'", m.Name, p.DotName())
+		}
+	}
+	if !m.IsFieldsUnique(m.PaginateBy) {
+		ctx.AddError("Model '%s' pagination key '%s' is not unique. Add a unique constraint or include the primary key fields.", m.Name, describeIndex(m.PaginateBy))
+	}
+}
+
 func checkIndexes(ctx *gen.Context, m *schema.Model) {
 	seen := make(map[string]struct{})
 	for _, f := range m.Indexes {
 		desc := describeIndex(f.Fields)
+		from := fromMixin(&f.Extendable)
 
 		if _, ok := seen[desc]; ok {
-			ctx.AddError("Model '%s' index '%s' is defined multiple times.", m.Name, desc)
+			ctx.AddError("Model '%s' index '%s'%s is defined multiple times.", m.Name, desc, from)
 		}
 		seen[desc] = struct{}{}
 
 		for _, path := range f.Fields {
 			c := m.FindField(path)
 			if c == nil {
-				ctx.AddError("Model '%s' index '%s' references unknown field '%s'", m.Name, desc, path.DotName())
+				ctx.AddError("Model '%s' index '%s'%s references unknown field '%s'", m.Name, desc, from, path.DotName())
 			}
 		}
 	}
@@ -152,16 +173,17 @@ func checkUniques(ctx *gen.Context, m *schema.Model) {
 	seen := make(map[string]struct{})
 	for _, f := range m.Uniques {
 		desc := describeIndex(f.Fields)
+		from := fromMixin(&f.Extendable)
 
 		if _, ok := seen[desc]; ok {
-			ctx.AddError("Model '%s' unique '%s' is defined multiple times.", m.Name, desc)
+			ctx.AddError("Model '%s' unique '%s'%s is defined multiple times.", m.Name, desc, from)
 		}
 		seen[desc] = struct{}{}
 
 		for _, path := range f.Fields {
 			c := m.FindField(path)
 			if c == nil {
-				ctx.AddError("Model '%s' unique '%s' references unknown field '%s'", m.Name, desc, path.DotName())
+				ctx.AddError("Model '%s' unique '%s'%s references unknown field '%s'", m.Name, desc, from, path.DotName())
 			}
 		}
 	}
@@ -171,19 +193,20 @@ func checkForeignKeys(ctx *gen.Context, m *schema.Model) {
 	seen := make(map[string]struct{})
 	for _, f := range m.ForeignKeys {
 		desc := strings.Join(dotNameAll(f.LocalFields), ", ")
+		from := fromMixin(&f.Extendable)
 
 		if len(f.LocalFields) == 0 {
-			ctx.AddError("Model '%s' foreign key '%s': local field list is empty", m.Name, desc)
+			ctx.AddError("Model '%s' foreign key '%s'%s: local field list is empty", m.Name, desc, from)
 		}
 		for _, p := range f.LocalFields {
 			if f := m.FindField(p); f == nil {
-				ctx.AddError("Model '%s' foreign key '%s': local field '%s' does not exist", m.Name, desc, p.DotName())
+				ctx.AddError("Model '%s' foreign key '%s'%s: local field '%s' does not exist", m.Name, desc, from, p.DotName())
 			}
 		}
 
 		m2, ok := ctx.Schema.Models[f.ForeignModel]
 		if !ok {
-			ctx.AddError("Model '%s' foreign key '%s': foreign model '%s' does not exist", m.Name, desc, f.ForeignModel)
+			ctx.AddError("Model '%s' foreign key '%s'%s: foreign model '%s' does not exist", m.Name, desc, from, f.ForeignModel)
 			continue
 		}
 		if f.ForeignFields == nil && m2.PrimaryKey != nil {
@@ -191,16 +214,16 @@ func checkForeignKeys(ctx *gen.Context, m *schema.Model) {
 		}
 
 		if len(f.ForeignFields) == 0 {
-			ctx.AddError("Model '%s' foreign key '%s': foreign field list is empty", m.Name, desc)
+			ctx.AddError("Model '%s' foreign key '%s'%s: foreign field list is empty", m.Name, desc, from)
 		}
 		for _, p := range f.ForeignFields {
 			if f := m2.FindField(p); f == nil {
-				ctx.AddError("Model '%s' foreign key '%s': foreign field '%s' does not exist", m.Name, desc, p.DotName())
+				ctx.AddError("Model '%s' foreign key '%s'%s: foreign field '%s' does not exist", m.Name, desc, from, p.DotName())
 			}
 		}
 
 		if len(f.LocalFields) != len(f.ForeignFields) {
-			ctx.AddError("Model '%s' foreign key '%s': local (%d) and foreign (%d) field count doesn't match", m.Name, desc, len(f.LocalFields), len(f.ForeignFields))
+			ctx.AddError("Model '%s' foreign key '%s'%s: local (%d) and foreign (%d) field count doesn't match", m.Name, desc, from, len(f.LocalFields), len(f.ForeignFields))
 			continue // Do not compare types if count doesn't match
 		}
 
@@ -211,12 +234,12 @@ func checkForeignKeys(ctx *gen.Context, m *schema.Model) {
 				continue // Ignore these errors, they've already been reported before.
 			}
 			if ff.Type != lf.Type {
-				ctx.AddError("Model '%s' foreign key '%s': local field '%s' and foreign field '%s' have different types: %+v %+v", m.Name, desc, f.LocalFields[i], f.ForeignFields[i], lf.Type, ff.Type)
+				ctx.AddError("Model '%s' foreign key '%s'%s: local field '%s' and foreign field '%s' have different types: %+v %+v", m.Name, desc, from, f.LocalFields[i], f.ForeignFields[i], lf.Type, ff.Type)
 			}
 		}
 
 		if _, ok := seen[desc]; ok {
-			ctx.AddError("Model '%s' foreign key '%s' is defined multiple times.", m.Name, describeIndex(f.LocalFields))
+			ctx.AddError("Model '%s' foreign key '%s'%s is defined multiple times.", m.Name, describeIndex(f.LocalFields), from)
 		}
 		seen[desc] = struct{}{}
 	}
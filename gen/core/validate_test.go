@@ -0,0 +1,39 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sqlbunny/sqlbunny/gen"
+)
+
+func TestMixinErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := buildSchema([]gen.ConfigItem{
+		Type("string", BaseType{
+			Go:       "string",
+			GoNull:   "github.com/sqlbunny/sqlbunny/types/null.String",
+			Postgres: SQLType{Type: "text", ZeroValue: "''"},
+		}),
+		Mixin("base",
+			Field("id", "string", Null, PrimaryKey),
+			Index("missing"),
+		),
+		Model("book",
+			Use("base"),
+		),
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	for _, want := range []string{
+		"Model 'book' primary key (from mixin 'base') references nullable field 'id'",
+		"Model 'book' index 'missing' (from mixin 'base') references unknown field 'missing'",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error doesn't contain %q:\n%v", want, err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/sqlbunny/sqlbunny/gen"
+)
+
+// Plugin writes a JSON Schema (draft 2020-12) document and an OpenAPI 3
+// document with the schemas of all the models, structs and enums. The schemas
+// describe the JSON produced by the generated Go types.
+type Plugin struct {
+	// OutputPath is the directory where the files are written. Defaults to "./schemas".
+	OutputPath string
+
+	// JSONSchemaFilename is the JSON Schema file name. Defaults to "schema.json".
+	JSONSchemaFilename string
+
+	// OpenAPIFilename is the OpenAPI file name. Defaults to "openapi.json".
+	OpenAPIFilename string
+
+	// Types overrides the schema emitted for a base type, keyed by the sqlbunny type name.
+	// Use it for custom types whose JSON representation can't be inferred from their Go type.
+	Types map[string]map[string]interface{}
+}
+
+var _ gen.Plugin = &Plugin{}
+
+func (*Plugin) ConfigItem(ctx *gen.Context) {}
+
+func (p *Plugin) BunnyPlugin() {
+	if p.OutputPath == "" {
+		p.OutputPath = "./schemas"
+	}
+	if p.JSONSchemaFilename == "" {
+		p.JSONSchemaFilename = "schema.json"
+	}
+	if p.OpenAPIFilename == "" {
+		p.OpenAPIFilename = "openapi.json"
+	}
+
+	gen.OnGen(p.gen)
+}
+
+func (p *Plugin) gen() {
+	if err := os.MkdirAll(p.OutputPath, os.ModePerm); err != nil {
+		log.Fatalf("Error creating output directory %s: %v", p.OutputPath, err)
+	}
+
+	s := gen.Config.Schema
+
+	p.writeFile(p.JSONSchemaFilename, map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$defs":   p.buildSchemas(s, jsonSchemaFlavor),
+	})
+
+	p.writeFile(p.OpenAPIFilename, map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   gen.Config.ModelsPackageName,
+			"version": "1.0.0",
+		},
+		"paths": map[string]interface{}{},
+		"components": map[string]interface{}{
+			"schemas": p.buildSchemas(s, openAPIFlavor),
+		},
+	})
+}
+
+func (p *Plugin) writeFile(filename string, doc interface{}) {
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		log.Fatalf("Error encoding %s: %v", filename, err)
+	}
+	out = append(out, '\n')
+
+	path := filepath.Join(p.OutputPath, filename)
+	if err := ioutil.WriteFile(path, out, 0666); err != nil {
+		log.Fatalf("failed to write output file %s: %v", path, err)
+	}
+}
@@ -0,0 +1,177 @@
+package jsonschema
+
+import (
+	"github.com/sqlbunny/sqlbunny/runtime/strmangle"
+	"github.com/sqlbunny/sqlbunny/schema"
+)
+
+// flavor holds the differences between plain JSON Schema and
+// the OpenAPI 3.0 schema object dialect.
+type flavor struct {
+	refPrefix string
+	openAPI   bool
+}
+
+var (
+	jsonSchemaFlavor = flavor{refPrefix: "#/$defs/"}
+	openAPIFlavor    = flavor{refPrefix: "#/components/schemas/", openAPI: true}
+)
+
+func (fl flavor) ref(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"$ref": fl.refPrefix + strmangle.TitleCase(name),
+	}
+}
+
+// nullable returns a schema that accepts everything s accepts, plus null.
+func (fl flavor) nullable(s map[string]interface{}) map[string]interface{} {
+	_, isRef := s["$ref"]
+
+	if fl.openAPI {
+		// OpenAPI 3.0 ignores siblings of $ref, so it has to be wrapped.
+		if isRef {
+			return map[string]interface{}{
+				"allOf":    []interface{}{s},
+				"nullable": true,
+			}
+		}
+		res := copySchema(s)
+		res["nullable"] = true
+		if enum, ok := res["enum"].([]interface{}); ok {
+			res["enum"] = append(append([]interface{}(nil), enum...), nil)
+		}
+		return res
+	}
+
+	t, isType := s["type"].(string)
+	if isRef || !isType || s["enum"] != nil {
+		return map[string]interface{}{
+			"anyOf": []interface{}{s, map[string]interface{}{"type": "null"}},
+		}
+	}
+	res := copySchema(s)
+	res["type"] = []interface{}{t, "null"}
+	return res
+}
+
+func copySchema(s map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(s))
+	for k, v := range s {
+		res[k] = v
+	}
+	return res
+}
+
+func (p *Plugin) buildSchemas(s *schema.Schema, fl flavor) map[string]interface{} {
+	res := make(map[string]interface{})
+
+	for _, t := range s.Types {
+		switch t := t.(type) {
+		case *schema.Enum:
+			res[strmangle.TitleCase(t.Name)] = enumSchema(t)
+		case *schema.Struct:
+			res[strmangle.TitleCase(t.Name)] = p.objectSchema(t.Fields, fl)
+		}
+	}
+
+	for _, m := range s.Models {
+		res[strmangle.TitleCase(m.Name)] = p.objectSchema(m.Fields, fl)
+	}
+
+	return res
+}
+
+func enumSchema(e *schema.Enum) map[string]interface{} {
+	choices := make([]interface{}, len(e.Choices))
+	for i, c := range e.Choices {
+		choices[i] = c
+	}
+	return map[string]interface{}{
+		"type": "string",
+		"enum": choices,
+	}
+}
+
+func (p *Plugin) objectSchema(fields []*schema.Field, fl flavor) map[string]interface{} {
+	props := make(map[string]interface{})
+	required := []interface{}{}
+
+	for _, f := range fields {
+		name, omitEmpty, ok := f.JSONName()
+		if !ok {
+			continue
+		}
+
+		s := p.typeSchema(f.Type, fl)
+		if f.Nullable {
+			s = fl.nullable(s)
+		}
+		props[name] = s
+
+		if !omitEmpty {
+			required = append(required, name)
+		}
+	}
+
+	res := map[string]interface{}{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+	if len(required) != 0 {
+		res["required"] = required
+	}
+	return res
+}
+
+func (p *Plugin) typeSchema(t schema.Type, fl flavor) map[string]interface{} {
+	if s, ok := p.Types[t.GetName()]; ok {
+		return copySchema(s)
+	}
+
+	switch t := t.(type) {
+	case *schema.Enum, *schema.Struct:
+		return fl.ref(t.GetName())
+	}
+
+	return goTypeSchema(t.GoType(), fl)
+}
+
+func goTypeSchema(t schema.GoType, fl flavor) map[string]interface{} {
+	name := t.Name
+	if t.Pkg != "" {
+		name = t.Pkg + "." + t.Name
+	}
+
+	switch name {
+	case "string":
+		return map[string]interface{}{"type": "string"}
+	case "bool":
+		return map[string]interface{}{"type": "boolean"}
+	case "int8", "int16", "int32", "uint8", "uint16":
+		return formatted("integer", "int32", fl)
+	case "int", "int64", "uint", "uint32", "uint64":
+		return formatted("integer", "int64", fl)
+	case "float32":
+		return formatted("number", "float", fl)
+	case "float64":
+		return formatted("number", "double", fl)
+	case "[]byte":
+		if fl.openAPI {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+	case "time.Time":
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	// Unknown types, and types.JSON, can hold any JSON value.
+	return map[string]interface{}{}
+}
+
+func formatted(typ, format string, fl flavor) map[string]interface{} {
+	if fl.openAPI {
+		return map[string]interface{}{"type": typ, "format": format}
+	}
+	return map[string]interface{}{"type": typ}
+}
@@ -0,0 +1,90 @@
+package protobuf
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/sqlbunny/errors"
+)
+
+// lockFile records the numbers assigned to the fields of each message, so they
+// don't change when fields are added, removed or reordered in the schema.
+// Numbers are only appended, the ones of removed fields are kept and reserved.
+type lockFile struct {
+	Messages map[string]map[string]*lockedField `json:"messages"`
+}
+
+type lockedField struct {
+	Number int    `json:"number"`
+	Type   string `json:"type"`
+}
+
+func readLockFile(path string) (*lockFile, error) {
+	lock := &lockFile{}
+	b, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(b, lock); err != nil {
+			return nil, errors.Errorf("invalid lock file %s: %w", path, err)
+		}
+	}
+	if lock.Messages == nil {
+		lock.Messages = make(map[string]map[string]*lockedField)
+	}
+	return lock, nil
+}
+
+func (l *lockFile) write(path string) error {
+	b, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(b, '\n'), 0666)
+}
+
+// fieldNumber returns the number of the field in the message, assigning the next
+// one if it's new. It errors if the field was locked with another type, since
+// messages serialized with the old type would be decoded wrongly.
+func (l *lockFile) fieldNumber(message string, f *protoField) (int, error) {
+	fields := l.Messages[message]
+	if fields == nil {
+		fields = make(map[string]*lockedField)
+		l.Messages[message] = fields
+	}
+
+	if lf, ok := fields[f.Name]; ok {
+		if lf.Type != f.Type() {
+			return 0, errors.Errorf("field %s.%s = %d changed type from %s to %s, rename the field to give it a new number", message, f.Name, lf.Number, lf.Type, f.Type())
+		}
+		return lf.Number, nil
+	}
+
+	next := 1
+	for _, lf := range fields {
+		if lf.Number >= next {
+			next = lf.Number + 1
+		}
+	}
+	fields[f.Name] = &lockedField{Number: next, Type: f.Type()}
+	return next, nil
+}
+
+// removed returns the names of the fields locked in the message that are not in
+// present, sorted by number.
+func (l *lockFile) removed(message string, present map[string]struct{}) []string {
+	fields := l.Messages[message]
+	var res []string
+	for name := range fields {
+		if _, ok := present[name]; !ok {
+			res = append(res, name)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return fields[res[i]].Number < fields[res[j]].Number
+	})
+	return res
+}
@@ -0,0 +1,202 @@
+package protobuf
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sqlbunny/sqlbunny/gen"
+	"github.com/sqlbunny/sqlbunny/schema"
+)
+
+const (
+	templatesPackage = "github.com/sqlbunny/sqlbunny/gen/protobuf"
+)
+
+// Plugin writes a .proto file with a message for every model and struct
+// and an enum for every enum, and generates ToProto and <Type>FromProto
+// converters next to each generated type.
+//
+// Nullable fields map to the google.protobuf wrapper types, time.Time
+// maps to google.protobuf.Timestamp, and enums keep their stored values.
+type Plugin struct {
+	// OutputPath is the directory where the .proto file is written. Defaults to "./proto".
+	OutputPath string
+
+	// Filename is the .proto file name. Defaults to "models.proto".
+	Filename string
+
+	// Package is the protobuf package. Defaults to the models package name.
+	Package string
+
+	// GoPackage is the import path of the Go package protoc-gen-go generates
+	// from the .proto file. The converters reference its types. It must be set.
+	GoPackage string
+
+	// GoPackageName is the name the converters import GoPackage as. Defaults to "pb".
+	GoPackageName string
+
+	// LockFile is the file recording the field numbers of every message, so they
+	// stay the same when fields are added, removed or reordered. It must be
+	// checked in along with the .proto file. Defaults to the .proto file path
+	// with a ".lock.json" extension.
+	LockFile string
+}
+
+var _ gen.Plugin = &Plugin{}
+
+func (*Plugin) ConfigItem(ctx *gen.Context) {}
+
+func (p *Plugin) BunnyPlugin() {
+	if p.GoPackage == "" {
+		log.Fatal("protobuf.Plugin.GoPackage is not set.")
+	}
+	if p.OutputPath == "" {
+		p.OutputPath = "./proto"
+	}
+	if p.Filename == "" {
+		p.Filename = "models.proto"
+	}
+	if p.Package == "" {
+		p.Package = gen.Config.ModelsPackageName
+	}
+	if p.GoPackageName == "" {
+		p.GoPackageName = "pb"
+	}
+	if p.LockFile == "" {
+		p.LockFile = filepath.Join(p.OutputPath, strings.TrimSuffix(p.Filename, filepath.Ext(p.Filename))+".lock.json")
+	}
+
+	gen.TemplateFunctions["protoFields"] = func(fields []*schema.Field) []*protoField {
+		return protoFields(p.GoPackageName, fields)
+	}
+
+	gen.OnHook("model", p.hook(gen.MustLoadTemplate(templatesPackage, "templates/model.tpl")))
+	gen.OnHook("struct", p.hook(gen.MustLoadTemplate(templatesPackage, "templates/struct.tpl")))
+	gen.OnHook("enum", p.hook(gen.MustLoadTemplate(templatesPackage, "templates/enum.tpl")))
+	gen.OnGen(p.gen)
+}
+
+func (p *Plugin) hook(tpl *gen.TemplateList) gen.HookFunc {
+	return func(buf *bytes.Buffer, data map[string]interface{}, args ...interface{}) {
+		data2 := make(map[string]interface{})
+		for k, v := range data {
+			data2[k] = v
+		}
+		data2["ProtoPackage"] = p.GoPackage
+		data2["ProtoPackageName"] = p.GoPackageName
+		tpl.ExecuteBuf(data2, buf)
+	}
+}
+
+func (p *Plugin) gen() {
+	if err := os.MkdirAll(p.OutputPath, os.ModePerm); err != nil {
+		log.Fatalf("Error creating output directory %s: %v", p.OutputPath, err)
+	}
+
+	path := filepath.Join(p.OutputPath, p.Filename)
+	if _, err := os.Stat(p.LockFile); os.IsNotExist(err) {
+		if _, err := os.Stat(path); err == nil {
+			log.Fatalf("protobuf: lock file %s is missing but %s exists, restore the lock file or delete %s to number the fields from scratch", p.LockFile, path, path)
+		}
+	}
+	lock, err := readLockFile(p.LockFile)
+	if err != nil {
+		log.Fatalf("protobuf: failed to read lock file %s: %v", p.LockFile, err)
+	}
+
+	s := gen.Config.Schema
+
+	imports := make(map[string]struct{})
+	var body bytes.Buffer
+
+	for _, name := range s.SortedTypeNames() {
+		switch t := s.Types[name].(type) {
+		case *schema.Enum:
+			writeEnum(&body, t)
+		case *schema.Struct:
+			if err := writeMessage(&body, imports, lock, t.Name, t.Fields); err != nil {
+				log.Fatalf("protobuf: %v", err)
+			}
+		}
+	}
+	for _, name := range s.SortedModelNames() {
+		m := s.Models[name]
+		if err := writeMessage(&body, imports, lock, m.Name, m.Fields); err != nil {
+			log.Fatalf("protobuf: %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by sqlbunny (https://github.com/sqlbunny/sqlbunny). DO NOT EDIT.\n\n")
+	out.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&out, "package %s;\n\n", p.Package)
+	fmt.Fprintf(&out, "option go_package = %q;\n\n", p.GoPackage)
+	for _, imp := range sortedKeys(imports) {
+		fmt.Fprintf(&out, "import %q;\n", imp)
+	}
+	if len(imports) != 0 {
+		out.WriteByte('\n')
+	}
+	out.Write(body.Bytes())
+
+	if err := ioutil.WriteFile(path, out.Bytes(), 0666); err != nil {
+		log.Fatalf("failed to write output file %s: %v", path, err)
+	}
+	if err := lock.write(p.LockFile); err != nil {
+		log.Fatalf("failed to write lock file %s: %v", p.LockFile, err)
+	}
+}
+
+func writeEnum(buf *bytes.Buffer, e *schema.Enum) {
+	fmt.Fprintf(buf, "enum %s {\n", messageName(e.Name))
+	for i, c := range e.Choices {
+		fmt.Fprintf(buf, "  %s = %d;\n", enumValueName(e.Name, c), i)
+	}
+	buf.WriteString("}\n\n")
+}
+
+// writeMessage writes the message with the field numbers from lock, and reserves
+// the numbers and names of the locked fields no longer in the message.
+func writeMessage(buf *bytes.Buffer, imports map[string]struct{}, lock *lockFile, name string, fields []*schema.Field) error {
+	message := messageName(name)
+	present := make(map[string]struct{})
+
+	fmt.Fprintf(buf, "message %s {\n", message)
+	for _, field := range fields {
+		f, ok := newProtoField("", field)
+		if !ok {
+			log.Printf("Warning: protobuf: skipping '%s' field '%s', type '%s' has no protobuf mapping", name, field.Name, field.Type.GetName())
+			continue
+		}
+		number, err := lock.fieldNumber(message, f)
+		if err != nil {
+			return err
+		}
+		present[f.Name] = struct{}{}
+		if imp := f.Import(); imp != "" {
+			imports[imp] = struct{}{}
+		}
+		fmt.Fprintf(buf, "  %s %s = %d;\n", f.Type(), f.Name, number)
+	}
+	for _, removed := range lock.removed(message, present) {
+		fmt.Fprintf(buf, "  reserved %d;\n", lock.Messages[message][removed].Number)
+		fmt.Fprintf(buf, "  reserved %q;\n", removed)
+	}
+	buf.WriteString("}\n\n")
+	return nil
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	var res []string
+	for k := range m {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,13 @@
+{{- import .ProtoPackageName .ProtoPackage }}
+{{- $enumName := .Enum.Name | titleCase -}}
+{{- $pb := .ProtoPackageName }}
+
+// ToProto converts the {{$enumName}} to its protobuf enum. The numeric values are the stored values.
+func (o {{$enumName}}) ToProto() {{$pb}}.{{$enumName}} {
+	return {{$pb}}.{{$enumName}}(o)
+}
+
+// {{$enumName}}FromProto converts a protobuf enum to a {{$enumName}}.
+func {{$enumName}}FromProto(p {{$pb}}.{{$enumName}}) {{$enumName}} {
+	return {{$enumName}}(p)
+}
@@ -0,0 +1,31 @@
+{{- import .ProtoPackageName .ProtoPackage }}
+{{- import "timestamppb" "google.golang.org/protobuf/types/known/timestamppb" }}
+{{- import "wrapperspb" "google.golang.org/protobuf/types/known/wrapperspb" }}
+{{- $modelName := .Model.Name | titleCase -}}
+{{- $pb := .ProtoPackageName }}
+
+// ToProto converts the {{$modelName}} to its protobuf message.
+func (o *{{$modelName}}) ToProto() *{{$pb}}.{{$modelName}} {
+	if o == nil {
+		return nil
+	}
+
+	p := &{{$pb}}.{{$modelName}}{}
+	{{- range protoFields .Model.Fields }}
+	{{ .ToProto "o" "p" }}
+	{{- end }}
+	return p
+}
+
+// {{$modelName}}FromProto converts a protobuf message to a {{$modelName}}.
+func {{$modelName}}FromProto(p *{{$pb}}.{{$modelName}}) *{{$modelName}} {
+	if p == nil {
+		return nil
+	}
+
+	o := &{{$modelName}}{}
+	{{- range protoFields .Model.Fields }}
+	{{ .FromProto "o" "p" (goType .Field.Type.GoType) }}
+	{{- end }}
+	return o
+}
@@ -0,0 +1,28 @@
+{{- import .ProtoPackageName .ProtoPackage }}
+{{- import "timestamppb" "google.golang.org/protobuf/types/known/timestamppb" }}
+{{- import "wrapperspb" "google.golang.org/protobuf/types/known/wrapperspb" }}
+{{- $structName := .Struct.Name | titleCase -}}
+{{- $pb := .ProtoPackageName }}
+
+// ToProto converts the {{$structName}} to its protobuf message.
+func (o {{$structName}}) ToProto() *{{$pb}}.{{$structName}} {
+	p := &{{$pb}}.{{$structName}}{}
+	{{- range protoFields .Struct.Fields }}
+	{{ .ToProto "o" "p" }}
+	{{- end }}
+	return p
+}
+
+// {{$structName}}FromProto converts a protobuf message to a {{$structName}}.
+// A nil message converts to the zero value.
+func {{$structName}}FromProto(p *{{$pb}}.{{$structName}}) {{$structName}} {
+	var o {{$structName}}
+	if p == nil {
+		return o
+	}
+
+	{{- range protoFields .Struct.Fields }}
+	{{ .FromProto "o" "p" (goType .Field.Type.GoType) }}
+	{{- end }}
+	return o
+}
@@ -0,0 +1,269 @@
+package protobuf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sqlbunny/sqlbunny/runtime/strmangle"
+	"github.com/sqlbunny/sqlbunny/schema"
+)
+
+const (
+	timestampImport = "google/protobuf/timestamp.proto"
+	wrappersImport  = "google/protobuf/wrappers.proto"
+)
+
+// scalar describes how a Go base type maps to protobuf.
+type scalar struct {
+	// Proto is the protobuf scalar type.
+	Proto string
+	// Go is the Go type protoc-gen-go uses for Proto.
+	Go string
+	// Wrapper is the google.protobuf wrapper message used for the nullable variant.
+	Wrapper string
+}
+
+var scalars = map[string]scalar{
+	"string":  {"string", "string", "String"},
+	"bool":    {"bool", "bool", "Bool"},
+	"int8":    {"int32", "int32", "Int32"},
+	"int16":   {"int32", "int32", "Int32"},
+	"int32":   {"int32", "int32", "Int32"},
+	"int":     {"int64", "int64", "Int64"},
+	"int64":   {"int64", "int64", "Int64"},
+	"byte":    {"uint32", "uint32", "UInt32"},
+	"uint8":   {"uint32", "uint32", "UInt32"},
+	"uint16":  {"uint32", "uint32", "UInt32"},
+	"uint32":  {"uint32", "uint32", "UInt32"},
+	"uint":    {"uint64", "uint64", "UInt64"},
+	"uint64":  {"uint64", "uint64", "UInt64"},
+	"float32": {"float", "float32", "Float"},
+	"float64": {"double", "float64", "Double"},
+	"[]byte":  {"bytes", "[]byte", "Bytes"},
+
+	"github.com/sqlbunny/sqlbunny/types.JSON": {"bytes", "[]byte", "Bytes"},
+}
+
+type fieldKind int
+
+const (
+	kindScalar fieldKind = iota
+	kindTime
+	kindEnum
+	kindStruct
+)
+
+// protoField is a model or struct field as it's represented in
+// the protobuf message.
+type protoField struct {
+	Field  *schema.Field
+	Name   string
+	GoName string
+
+	kind   fieldKind
+	scalar scalar
+	pkg    string
+}
+
+func goTypeName(t schema.GoType) string {
+	if t.Pkg == "" {
+		return t.Name
+	}
+	return t.Pkg + "." + t.Name
+}
+
+// protoFields returns the fields that can be represented in protobuf.
+func protoFields(pkg string, fields []*schema.Field) []*protoField {
+	var res []*protoField
+	for _, f := range fields {
+		if pf, ok := newProtoField(pkg, f); ok {
+			res = append(res, pf)
+		}
+	}
+	return res
+}
+
+// newProtoField returns the protobuf representation of the field.
+// Its number is assigned by the lock file, see lockFile.
+// ok is false if the field type has no protobuf mapping.
+func newProtoField(pkg string, f *schema.Field) (pf *protoField, ok bool) {
+	pf = &protoField{
+		Field:  f,
+		Name:   f.Name,
+		GoName: goCamelCase(f.Name),
+		pkg:    pkg,
+	}
+
+	switch t := f.Type.(type) {
+	case *schema.Enum:
+		pf.kind = kindEnum
+	case *schema.Struct:
+		pf.kind = kindStruct
+	default:
+		name := goTypeName(t.GoType())
+		if name == "time.Time" {
+			pf.kind = kindTime
+		} else if s, ok := scalars[name]; ok {
+			pf.kind = kindScalar
+			pf.scalar = s
+		} else {
+			return nil, false
+		}
+	}
+	return pf, true
+}
+
+// Type returns the protobuf type of the field, including the optional label.
+func (f *protoField) Type() string {
+	switch f.kind {
+	case kindTime:
+		return "google.protobuf.Timestamp"
+	case kindEnum:
+		if f.Field.Nullable {
+			return "optional " + messageName(f.Field.Type.GetName())
+		}
+		return messageName(f.Field.Type.GetName())
+	case kindStruct:
+		return messageName(f.Field.Type.GetName())
+	}
+	if f.Field.Nullable {
+		return "google.protobuf." + f.scalar.Wrapper + "Value"
+	}
+	return f.scalar.Proto
+}
+
+// Import returns the well-known proto file the field type requires, if any.
+func (f *protoField) Import() string {
+	switch {
+	case f.kind == kindTime:
+		return timestampImport
+	case f.kind == kindScalar && f.Field.Nullable:
+		return wrappersImport
+	}
+	return ""
+}
+
+// ToProto returns the Go statement that copies the field from
+// the sqlbunny value o to the protobuf message p.
+func (f *protoField) ToProto(o, p string) string {
+	src := o + "." + strmangle.TitleCase(f.Field.Name)
+	dst := p + "." + f.GoName
+
+	if f.Field.Nullable {
+		val := src + "." + f.Field.Type.(schema.NullableType).GoTypeNullField()
+		var conv string
+		switch f.kind {
+		case kindTime:
+			conv = "timestamppb.New(" + val + ")"
+		case kindEnum:
+			return fmt.Sprintf("if %s.Valid {\nv := %s.%s(%s)\n%s = &v\n}", src, f.pkg, messageName(f.Field.Type.GetName()), val, dst)
+		case kindStruct:
+			conv = val + ".ToProto()"
+		default:
+			conv = fmt.Sprintf("wrapperspb.%s(%s)", f.scalar.Wrapper, f.convert(val))
+		}
+		return fmt.Sprintf("if %s.Valid {\n%s = %s\n}", src, dst, conv)
+	}
+
+	switch f.kind {
+	case kindTime:
+		return fmt.Sprintf("%s = timestamppb.New(%s)", dst, src)
+	case kindEnum:
+		return fmt.Sprintf("%s = %s.%s(%s)", dst, f.pkg, messageName(f.Field.Type.GetName()), src)
+	case kindStruct:
+		return fmt.Sprintf("%s = %s.ToProto()", dst, src)
+	}
+	return fmt.Sprintf("%s = %s", dst, f.convert(src))
+}
+
+// convert returns the Go expression converting val, of the field Go type,
+// to the Go type protoc-gen-go uses for the scalar.
+func (f *protoField) convert(val string) string {
+	if goTypeName(f.Field.Type.GoType()) == f.scalar.Go {
+		return val
+	}
+	return f.scalar.Go + "(" + val + ")"
+}
+
+// FromProto returns the Go statement that copies the field from
+// the protobuf message p to the sqlbunny value o. goType is the
+// Go type of the (not nullable) sqlbunny type of the field.
+func (f *protoField) FromProto(o, p, goType string) string {
+	src := p + "." + f.GoName
+	dst := o + "." + strmangle.TitleCase(f.Field.Name)
+
+	var val string
+	switch f.kind {
+	case kindTime:
+		val = src + ".AsTime()"
+	case kindEnum:
+		if f.Field.Nullable {
+			val = goType + "(*" + src + ")"
+		} else {
+			val = goType + "(" + src + ")"
+		}
+	case kindStruct:
+		val = goType + "FromProto(" + src + ")"
+	default:
+		val = src
+		if f.Field.Nullable {
+			val = src + ".Value"
+		}
+		if goType != f.scalar.Go {
+			val = goType + "(" + val + ")"
+		}
+	}
+
+	if f.Field.Nullable {
+		nullField := f.Field.Type.(schema.NullableType).GoTypeNullField()
+		return fmt.Sprintf("if %s != nil {\n%s.%s = %s\n%s.Valid = true\n}", src, dst, nullField, val, dst)
+	}
+	if f.kind == kindTime {
+		return fmt.Sprintf("if %s != nil {\n%s = %s\n}", src, dst, val)
+	}
+	return fmt.Sprintf("%s = %s", dst, val)
+}
+
+func messageName(name string) string {
+	return strmangle.TitleCase(name)
+}
+
+// enumValueName returns the protobuf name of an enum choice. Enum values
+// are scoped to the package in protobuf, so they're prefixed with the
+// enum name.
+func enumValueName(enum string, choice string) string {
+	return strings.ToUpper(enum + "_" + choice)
+}
+
+// goCamelCase converts a protobuf name to the Go identifier protoc-gen-go uses for it.
+func goCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// Skip the underscore, the next letter is capitalized.
+		case isASCIIDigit(c):
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isASCIILower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isASCIILower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
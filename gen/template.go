@@ -158,9 +158,13 @@ var TemplateFunctions = template.FuncMap{
 		}
 		return res
 	},
-	"modelColumns":      modelColumns,
-	"modelPKColumns":    modelPKColumns,
-	"modelNonPKColumns": modelNonPKColumns,
+	"modelColumns":           modelColumns,
+	"modelPKColumns":         modelPKColumns,
+	"modelNonPKColumns":      modelNonPKColumns,
+	"modelPaginationColumns": modelPaginationColumns,
+	"modelByteaColumns":      modelByteaColumns,
+	"modelColumnTypes":       modelColumnTypes,
+	"modelAggregateColumns":  modelAggregateColumns,
 
 	"quotes": func(s string) string {
 		d := Config.Dialect
@@ -194,6 +198,21 @@ var TemplateFunctions = template.FuncMap{
 		f := ca.Type.(schema.NullableType).GoTypeNullField()
 		return a + ".Valid && " + a + "." + f + " == " + b
 	},
+	"loadKey": loadKey,
+	"scanKey": scanKey,
+	"doAssign": func(a, b string, ca, cb *schema.Field) string {
+		if ca.Nullable == cb.Nullable {
+			return a + " = " + b
+		}
+
+		if cb.Nullable {
+			f := cb.Type.(schema.NullableType).GoTypeNullField()
+			return a + " = " + b + "." + f
+		}
+
+		f := ca.Type.(schema.NullableType).GoTypeNullField()
+		return a + "." + f + " = " + b + "\n" + a + ".Valid = true"
+	},
 }
 
 func modelColumns(m *schema.Model) []string {
@@ -212,6 +231,26 @@ func modelPKColumns(m *schema.Model) []string {
 	return res
 }
 
+func modelPaginationColumns(m *schema.Model) []string {
+	var res []string
+	for _, path := range m.PaginationFields() {
+		res = append(res, path.SQLName())
+	}
+	return res
+}
+
+// modelByteaColumns returns the sorted names of the model columns stored as bytea.
+func modelByteaColumns(m *schema.Model) []string {
+	var res []string
+	for name, c := range m.Table.Columns {
+		if c.Type == "bytea" {
+			res = append(res, name)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
 func modelNonPKColumns(m *schema.Model) []string {
 	a := modelColumns(m)
 	b := modelPKColumns(m)
@@ -219,6 +258,149 @@ func modelNonPKColumns(m *schema.Model) []string {
 	return c
 }
 
+// loadKey returns an expression for the key of fields of obj, a model m, to match
+// related objects when eager loading. The values of nullable fields are used.
+func loadKey(obj string, fields []schema.Path, m *schema.Model) string {
+	exprs := make([]string, len(fields))
+	for i, p := range fields {
+		exprs[i] = fieldKey(obj+"."+titleCasePath(p), m.FindField(p))
+	}
+	return joinKeys(exprs)
+}
+
+// scanKey is loadKey for the values of fields of m scanned into the variables k0, k1...
+func scanKey(fields []schema.Path, m *schema.Model) string {
+	exprs := make([]string, len(fields))
+	for i, p := range fields {
+		exprs[i] = fieldKey(fmt.Sprintf("k%d", i), m.FindField(p))
+	}
+	return joinKeys(exprs)
+}
+
+func fieldKey(expr string, f *schema.Field) string {
+	if f.Nullable {
+		expr += "." + f.Type.(schema.NullableType).GoTypeNullField()
+	}
+	if f.Type.GoType().Name == "[]byte" {
+		expr = "string(" + expr + ")"
+	}
+	return expr
+}
+
+func joinKeys(exprs []string) string {
+	if len(exprs) == 1 {
+		return exprs[0]
+	}
+	return fmt.Sprintf("[%d]interface{}{%s}", len(exprs), strings.Join(exprs, ", "))
+}
+
+// ModelColumn is a column of a model table, with the Go type of the value it stores.
+type ModelColumn struct {
+	Name   string
+	GoType schema.GoType
+
+	// IsString is set if the column stores text.
+	IsString bool
+}
+
+// modelColumnTypes returns the columns of the model, including the ones
+// of nested structs, sorted by name.
+func modelColumnTypes(m *schema.Model) []ModelColumn {
+	var res []ModelColumn
+	for _, f := range m.Fields {
+		res = appendColumnTypes(res, f, false, nil)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+func appendColumnTypes(res []ModelColumn, f *schema.Field, forceNullable bool, prefix schema.Path) []ModelColumn {
+	path := append(append(schema.Path(nil), prefix...), f.Name)
+
+	if s, ok := f.Type.(*schema.Struct); ok && !f.IsJSON() {
+		for _, f2 := range s.Fields {
+			res = appendColumnTypes(res, f2, forceNullable || f.Nullable, path)
+		}
+		if f.Nullable {
+			res = append(res, ModelColumn{
+				Name:   path.SQLName(),
+				GoType: schema.GoType{Name: "bool"},
+			})
+		}
+		return res
+	}
+
+	t := f.Type.GoType()
+	if nt, ok := f.Type.(schema.NullableType); ok && (f.Nullable || forceNullable) {
+		t = nt.GoTypeNull()
+	}
+	return append(res, ModelColumn{
+		Name:     path.SQLName(),
+		GoType:   t,
+		IsString: f.Type.GoType() == schema.GoType{Name: "string"},
+	})
+}
+
+// AggregateColumn is a numeric or time column of a model table, with the Go types
+// of its aggregates. Aggregates are null when there are no rows.
+type AggregateColumn struct {
+	Name string
+	// MinMaxGoType is the nullable Go type of the column, returned by Min and Max.
+	MinMaxGoType schema.GoType
+	// SumGoType and AvgGoType are the types returned by Sum and Avg. They're
+	// empty for time columns, which can't be summed or averaged.
+	SumGoType schema.GoType
+	AvgGoType schema.GoType
+}
+
+const nullPkg = "github.com/sqlbunny/sqlbunny/types/null"
+
+var aggregateSumTypes = map[string]string{
+	"int": "Int64", "int8": "Int64", "int16": "Int64", "int32": "Int64", "int64": "Int64",
+	"uint": "Int64", "uint8": "Int64", "uint16": "Int64", "uint32": "Int64", "uint64": "Int64",
+	"float32": "Float64", "float64": "Float64",
+}
+
+// modelAggregateColumns returns the numeric and time columns of the model,
+// including the ones of nested structs, sorted by name.
+func modelAggregateColumns(m *schema.Model) []AggregateColumn {
+	var res []AggregateColumn
+	for _, f := range m.Fields {
+		res = appendAggregateColumns(res, f, nil)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+func appendAggregateColumns(res []AggregateColumn, f *schema.Field, prefix schema.Path) []AggregateColumn {
+	path := append(append(schema.Path(nil), prefix...), f.Name)
+
+	if s, ok := f.Type.(*schema.Struct); ok && !f.IsJSON() {
+		for _, f2 := range s.Fields {
+			res = appendAggregateColumns(res, f2, path)
+		}
+		return res
+	}
+
+	nt, ok := f.Type.(schema.NullableType)
+	if !ok {
+		return res
+	}
+	c := AggregateColumn{
+		Name:         path.SQLName(),
+		MinMaxGoType: nt.GoTypeNull(),
+	}
+	switch t := f.Type.GoType(); {
+	case t.Pkg == "" && aggregateSumTypes[t.Name] != "":
+		c.SumGoType = schema.GoType{Pkg: nullPkg, Name: aggregateSumTypes[t.Name]}
+		c.AvgGoType = schema.GoType{Pkg: nullPkg, Name: "Float64"}
+	case t == schema.GoType{Pkg: "time", Name: "Time"}:
+	default:
+		return res
+	}
+	return append(res, c)
+}
+
 func titleCasePath(p schema.Path) string {
 	var res = ""
 	for i, n := range p {
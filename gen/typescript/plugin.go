@@ -0,0 +1,74 @@
+package typescript
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/sqlbunny/sqlbunny/gen"
+	"github.com/sqlbunny/sqlbunny/schema"
+)
+
+// Plugin writes a TypeScript file with an interface for every model and
+// struct, and a string literal union for every enum. The definitions
+// describe the JSON produced by the generated Go types.
+type Plugin struct {
+	// OutputPath is the directory where the file is written. Defaults to "./ts".
+	OutputPath string
+
+	// Filename is the TypeScript file name. Defaults to "models.ts".
+	Filename string
+
+	// Types overrides the TypeScript type emitted for a base type, keyed by the sqlbunny type name.
+	// Use it for custom types whose JSON representation can't be inferred from their Go type.
+	Types map[string]string
+}
+
+var _ gen.Plugin = &Plugin{}
+
+func (*Plugin) ConfigItem(ctx *gen.Context) {}
+
+func (p *Plugin) BunnyPlugin() {
+	if p.OutputPath == "" {
+		p.OutputPath = "./ts"
+	}
+	if p.Filename == "" {
+		p.Filename = "models.ts"
+	}
+
+	gen.OnGen(p.gen)
+}
+
+func (p *Plugin) gen() {
+	if err := os.MkdirAll(p.OutputPath, os.ModePerm); err != nil {
+		log.Fatalf("Error creating output directory %s: %v", p.OutputPath, err)
+	}
+
+	s := gen.Config.Schema
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by sqlbunny (https://github.com/sqlbunny/sqlbunny). DO NOT EDIT.\n")
+
+	for _, name := range s.SortedTypeNames() {
+		switch t := s.Types[name].(type) {
+		case *schema.Enum:
+			buf.WriteByte('\n')
+			writeEnum(&buf, t)
+		case *schema.Struct:
+			buf.WriteByte('\n')
+			p.writeInterface(&buf, t.Name, t.Fields)
+		}
+	}
+	for _, name := range s.SortedModelNames() {
+		m := s.Models[name]
+		buf.WriteByte('\n')
+		p.writeInterface(&buf, m.Name, m.Fields)
+	}
+
+	path := filepath.Join(p.OutputPath, p.Filename)
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0666); err != nil {
+		log.Fatalf("failed to write output file %s: %v", path, err)
+	}
+}
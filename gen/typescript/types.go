@@ -0,0 +1,97 @@
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sqlbunny/sqlbunny/runtime/strmangle"
+	"github.com/sqlbunny/sqlbunny/schema"
+)
+
+func typeName(name string) string {
+	return strmangle.TitleCase(name)
+}
+
+func writeEnum(buf *bytes.Buffer, e *schema.Enum) {
+	choices := make([]string, len(e.Choices))
+	for i, c := range e.Choices {
+		choices[i] = strconv.Quote(c)
+	}
+	if len(choices) == 0 {
+		choices = append(choices, "never")
+	}
+	fmt.Fprintf(buf, "export type %s = %s;\n", typeName(e.Name), strings.Join(choices, " | "))
+}
+
+func (p *Plugin) writeInterface(buf *bytes.Buffer, name string, fields []*schema.Field) {
+	fmt.Fprintf(buf, "export interface %s {\n", typeName(name))
+	for _, f := range fields {
+		key, omitEmpty, ok := f.JSONName()
+		if !ok {
+			continue
+		}
+
+		t := p.fieldType(f.Type)
+		if f.Nullable {
+			t += " | null"
+		}
+
+		if omitEmpty {
+			fmt.Fprintf(buf, "  %s?: %s;\n", propertyName(key), t)
+		} else {
+			fmt.Fprintf(buf, "  %s: %s;\n", propertyName(key), t)
+		}
+	}
+	buf.WriteString("}\n")
+}
+
+// propertyName quotes the JSON key if it's not a valid identifier.
+func propertyName(key string) string {
+	for i, c := range key {
+		if c == '_' || c == '$' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || (i > 0 && '0' <= c && c <= '9') {
+			continue
+		}
+		return strconv.Quote(key)
+	}
+	if key == "" {
+		return `""`
+	}
+	return key
+}
+
+func (p *Plugin) fieldType(t schema.Type) string {
+	if s, ok := p.Types[t.GetName()]; ok {
+		return s
+	}
+
+	switch t := t.(type) {
+	case *schema.Enum, *schema.Struct:
+		return typeName(t.GetName())
+	}
+
+	return goTypeType(t.GoType())
+}
+
+func goTypeType(t schema.GoType) string {
+	name := t.Name
+	if t.Pkg != "" {
+		name = t.Pkg + "." + t.Name
+	}
+
+	switch name {
+	case "bool":
+		return "boolean"
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64", "byte":
+		return "number"
+	case "string", "[]byte", "time.Time":
+		// []byte is marshaled as base64, time.Time as RFC 3339.
+		return "string"
+	}
+
+	// Unknown types, and types.JSON, can hold any JSON value.
+	return "unknown"
+}
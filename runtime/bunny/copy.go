@@ -0,0 +1,127 @@
+package bunny
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sqlbunny/errors"
+)
+
+// CopyInThreshold is the number of rows from which the generated InsertAll
+// methods insert with COPY FROM STDIN, if the database supports it.
+var CopyInThreshold = 1000
+
+type preparer interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// CanCopyIn returns whether the database in the context supports CopyIn.
+func CanCopyIn(ctx context.Context) bool {
+	switch db := DBFromContext(ctx).(type) {
+	case *txNode:
+		_, ok := db.driver.(*pq.Driver)
+		return ok && db.child == nil
+	case driverer:
+		_, ok := db.Driver().(*pq.Driver)
+		_, ok2 := db.(beginTxer)
+		return ok && ok2
+	}
+	return false
+}
+
+// CopyIn inserts rows into the table with COPY FROM STDIN. The database in the context
+// must be a lib/pq database or a transaction started on one, see CanCopyIn.
+// A table name like schema.table is qualified with the schema.
+//
+// COPY sends []byte values as bytea, so the values of other columns,
+// like the ones stored as JSON, are sent as text unless the column is in byteaColumns.
+func CopyIn(ctx context.Context, table string, columns []string, byteaColumns []string, rows [][]interface{}) error {
+	if !CanCopyIn(ctx) {
+		return errors.New("database does not support COPY FROM STDIN")
+	}
+
+	bytea := make([]bool, len(columns))
+	for i, c := range columns {
+		for _, b := range byteaColumns {
+			if c == b {
+				bytea[i] = true
+			}
+		}
+	}
+
+	var query string
+	if i := strings.LastIndexByte(table, '.'); i != -1 {
+		query = pq.CopyInSchema(table[:i], table[i+1:], columns...)
+	} else {
+		query = pq.CopyIn(table, columns...)
+	}
+	begin := time.Now()
+
+	var err error
+	switch db := DBFromContext(ctx).(type) {
+	case *txNode:
+		err = copyIn(ctx, db.dbTx, query, bytea, rows)
+	case beginTxer:
+		var tx *sql.Tx
+		tx, err = db.BeginTx(ctx, nil)
+		if err != nil {
+			err = errors.Errorf("BeginTx failed: %w", err)
+			break
+		}
+		err = copyIn(ctx, tx, query, bytea, rows)
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}
+
+	err = errors.WithStack(err)
+	logger.LogQuery(ctx, QueryLogInfo{
+		Query:    query,
+		Duration: time.Since(begin),
+		Err:      err,
+	})
+	return err
+}
+
+func copyIn(ctx context.Context, db preparer, query string, bytea []bool, rows [][]interface{}) error {
+	stmt, err := db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, row := range rows {
+		args := make([]interface{}, len(row))
+		for i, v := range row {
+			if args[i], err = copyValue(v, bytea[i]); err != nil {
+				return err
+			}
+		}
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			return err
+		}
+	}
+
+	// Flush the buffered rows.
+	_, err = stmt.ExecContext(ctx)
+	return err
+}
+
+func copyValue(v interface{}, bytea bool) (interface{}, error) {
+	if valuer, ok := v.(driver.Valuer); ok {
+		var err error
+		if v, err = valuer.Value(); err != nil {
+			return nil, err
+		}
+	}
+	if b, ok := v.([]byte); ok && !bytea {
+		return string(b), nil
+	}
+	return v, nil
+}
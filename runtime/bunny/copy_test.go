@@ -0,0 +1,22 @@
+package bunny
+
+import (
+	"context"
+	"testing"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestCopyInUnsupported(t *testing.T) {
+	t.Parallel()
+
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := ContextWithDB(context.Background(), db)
+
+	if err := CopyIn(ctx, "t", []string{"a"}, nil, [][]interface{}{{1}}); err == nil {
+		t.Error("expected an error copying into a database without COPY support")
+	}
+}
@@ -3,6 +3,7 @@ package bunny
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"math/rand"
 	"time"
@@ -120,33 +121,67 @@ func shouldRetryTransaction(err error) bool {
 			return true
 		}
 	}
-	return false
+	// A statement cached by StmtCacheDB is stale, and it's prepared again on retry.
+	return isStalePlan(err)
 }
 
 type txNode struct {
 	dbTx     *sql.Tx
+	driver   driver.Driver // Driver of the database the transaction was started in, if known.
 	parent   *txNode
 	child    *txNode
 	depth    int
 	onCommit []func(context.Context) error
+
+	// Set if the transaction was started in a StmtCacheDB.
+	stmtDB *StmtCacheDB
+	stmts  *stmtCache
 }
 
 func (t *txNode) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
 	if t.child != nil {
 		panic("Transaction has a subtransaction active, can't run statements in it.")
 	}
+	if t.stmts != nil {
+		var res sql.Result
+		err := t.withStmt(ctx, query, func(stmt *sql.Stmt) error {
+			var err error
+			res, err = stmt.ExecContext(ctx, args...)
+			return err
+		})
+		return res, err
+	}
 	return t.dbTx.ExecContext(ctx, query, args...)
 }
 func (t *txNode) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
 	if t.child != nil {
 		panic("Transaction has a subtransaction active, can't run statements in it.")
 	}
+	if t.stmts != nil {
+		var res *sql.Rows
+		err := t.withStmt(ctx, query, func(stmt *sql.Stmt) error {
+			var err error
+			res, err = stmt.QueryContext(ctx, args...)
+			return err
+		})
+		return res, err
+	}
 	return t.dbTx.QueryContext(ctx, query, args...)
 }
 func (t *txNode) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
 	if t.child != nil {
 		panic("Transaction has a subtransaction active, can't run statements in it.")
 	}
+	if t.stmts != nil {
+		var res *sql.Row
+		_ = t.withStmt(ctx, query, func(stmt *sql.Stmt) error {
+			res = stmt.QueryRowContext(ctx, args...)
+			return res.Err()
+		})
+		if res != nil {
+			return res
+		}
+	}
 	return t.dbTx.QueryRowContext(ctx, query, args...)
 }
 
@@ -188,6 +223,10 @@ func (t *txNode) runOnCommit(ctx context.Context) error {
 	return nil
 }
 
+type driverer interface {
+	Driver() driver.Driver
+}
+
 type beginTxer interface {
 	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
 }
@@ -220,9 +259,19 @@ func doTransaction(ctx context.Context, fn func(ctx context.Context) error, read
 			dbTx:  tx,
 			depth: 0,
 		}
+		if d, ok := db.(driverer); ok {
+			node.driver = d.Driver()
+		}
+		if c, ok := db.(*StmtCacheDB); ok {
+			node.stmtDB = c
+			node.stmts = newStmtCache(c.size)
+		}
 	case *txNode:
 		node = &txNode{
 			dbTx:   db.dbTx,
+			driver: db.driver,
+			stmtDB: db.stmtDB,
+			stmts:  db.stmts,
 			parent: db,
 			depth:  db.depth + 1,
 		}
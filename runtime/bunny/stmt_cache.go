@@ -0,0 +1,239 @@
+package bunny
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/lib/pq"
+	"github.com/sqlbunny/errors"
+)
+
+// StmtCacheDB is a database that runs queries with prepared statements, kept in an
+// LRU cache by query, instead of sending the query text on every call.
+// Transactions started on it with Atomic keep their own cache while they last,
+// reusing the statements already cached on the database.
+//
+// Statements are prepared again when Postgres reports their cached plan is stale
+// after a schema change. Inside a transaction the error aborts it, so Atomic retries it.
+type StmtCacheDB struct {
+	*sql.DB
+	size  int
+	stmts *stmtCache
+}
+
+// DefaultStmtCacheSize is the number of statements StmtCacheDB caches when size is not positive.
+const DefaultStmtCacheSize = 100
+
+// NewStmtCacheDB returns db with a cache of at most size prepared statements.
+// Use it in place of db with ContextWithDB.
+func NewStmtCacheDB(db *sql.DB, size int) *StmtCacheDB {
+	if size <= 0 {
+		size = DefaultStmtCacheSize
+	}
+	return &StmtCacheDB{
+		DB:    db,
+		size:  size,
+		stmts: newStmtCache(size),
+	}
+}
+
+func (db *StmtCacheDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	var res sql.Result
+	err := db.withStmt(ctx, query, func(stmt *sql.Stmt) error {
+		var err error
+		res, err = stmt.ExecContext(ctx, args...)
+		return err
+	})
+	return res, err
+}
+
+func (db *StmtCacheDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	var res *sql.Rows
+	err := db.withStmt(ctx, query, func(stmt *sql.Stmt) error {
+		var err error
+		res, err = stmt.QueryContext(ctx, args...)
+		return err
+	})
+	return res, err
+}
+
+func (db *StmtCacheDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	var res *sql.Row
+	_ = db.withStmt(ctx, query, func(stmt *sql.Stmt) error {
+		res = stmt.QueryRowContext(ctx, args...)
+		return res.Err()
+	})
+	if res == nil {
+		// The statement couldn't be prepared. Run the query unprepared
+		// to return the error in a row.
+		return db.DB.QueryRowContext(ctx, query, args...)
+	}
+	return res
+}
+
+// Close closes the cached statements and the database.
+func (db *StmtCacheDB) Close() error {
+	db.stmts.close()
+	return db.DB.Close()
+}
+
+func (db *StmtCacheDB) withStmt(ctx context.Context, query string, fn func(*sql.Stmt) error) error {
+	for try := 0; ; try++ {
+		stmt, release, err := db.stmts.get(ctx, query, db.DB.PrepareContext)
+		if err != nil {
+			return err
+		}
+		err = fn(stmt)
+		release()
+		if try == 0 && isStalePlan(err) {
+			db.stmts.evict(query)
+			continue
+		}
+		return err
+	}
+}
+
+// txStmt returns the statement for query in the transaction, reusing the one
+// prepared on the database if it's cached.
+func (db *StmtCacheDB) txStmt(ctx context.Context, tx *sql.Tx, query string) (*sql.Stmt, error) {
+	stmt, release, ok := db.stmts.lookup(query)
+	if !ok {
+		return tx.PrepareContext(ctx, query)
+	}
+	defer release()
+	return tx.StmtContext(ctx, stmt), nil
+}
+
+// withStmt runs fn with the cached statement for query in the transaction.
+func (t *txNode) withStmt(ctx context.Context, query string, fn func(*sql.Stmt) error) error {
+	stmt, release, err := t.stmts.get(ctx, query, func(ctx context.Context, query string) (*sql.Stmt, error) {
+		return t.stmtDB.txStmt(ctx, t.dbTx, query)
+	})
+	if err != nil {
+		return err
+	}
+	err = fn(stmt)
+	release()
+	if isStalePlan(err) {
+		// The transaction is aborted, drop the statement so Atomic's retry prepares it again.
+		t.stmts.evict(query)
+		t.stmtDB.stmts.evict(query)
+	}
+	return err
+}
+
+// isStalePlan returns whether err is the error Postgres returns when running a prepared
+// statement whose result type changed since it was prepared.
+func isStalePlan(err error) bool {
+	var pqerr *pq.Error
+	return errors.As(err, &pqerr) && pqerr.Code == "0A000" && pqerr.Message == "cached plan must not change result type"
+}
+
+// stmtCache is an LRU cache of prepared statements, keyed by query.
+type stmtCache struct {
+	mu    sync.Mutex
+	size  int
+	lru   *list.List // of *stmtEntry, most recently used first.
+	items map[string]*list.Element
+}
+
+type stmtEntry struct {
+	query string
+	stmt  *sql.Stmt
+	// refs counts the users of the statement, which is closed once it's
+	// evicted and unused.
+	refs    int
+	evicted bool
+}
+
+func newStmtCache(size int) *stmtCache {
+	return &stmtCache{
+		size:  size,
+		lru:   list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// lookup returns the cached statement for query, if any, and a function to call
+// once the statement is no longer used.
+func (c *stmtCache) lookup(query string) (*sql.Stmt, func(), bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[query]
+	if !ok {
+		return nil, nil, false
+	}
+	c.lru.MoveToFront(el)
+	e := el.Value.(*stmtEntry)
+	e.refs++
+	return e.stmt, func() { c.release(e) }, true
+}
+
+// get returns the statement for query, preparing it with prepare if it's not cached,
+// and a function to call once the statement is no longer used.
+func (c *stmtCache) get(ctx context.Context, query string, prepare func(ctx context.Context, query string) (*sql.Stmt, error)) (*sql.Stmt, func(), error) {
+	if stmt, release, ok := c.lookup(query); ok {
+		return stmt, release, nil
+	}
+
+	stmt, err := prepare(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[query]; ok {
+		// Prepared concurrently, keep the cached one.
+		_ = stmt.Close()
+		c.lru.MoveToFront(el)
+		e := el.Value.(*stmtEntry)
+		e.refs++
+		return e.stmt, func() { c.release(e) }, nil
+	}
+
+	e := &stmtEntry{query: query, stmt: stmt, refs: 1}
+	c.items[query] = c.lru.PushFront(e)
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+	return e.stmt, func() { c.release(e) }, nil
+}
+
+func (c *stmtCache) release(e *stmtEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e.refs--
+	if e.evicted && e.refs == 0 {
+		_ = e.stmt.Close()
+	}
+}
+
+// evict removes the statement for query from the cache.
+func (c *stmtCache) evict(query string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[query]; ok {
+		c.remove(el)
+	}
+}
+
+// close evicts all the statements.
+func (c *stmtCache) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.lru.Len() != 0 {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *stmtCache) remove(el *list.Element) {
+	e := c.lru.Remove(el).(*stmtEntry)
+	delete(c.items, e.query)
+	e.evicted = true
+	if e.refs == 0 {
+		_ = e.stmt.Close()
+	}
+}
@@ -0,0 +1,145 @@
+package bunny
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lib/pq"
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func newMockStmtCacheDB(t *testing.T, size int) (*StmtCacheDB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewStmtCacheDB(db, size), mock
+}
+
+func TestStmtCacheHit(t *testing.T) {
+	t.Parallel()
+
+	db, mock := newMockStmtCacheDB(t, 2)
+	ctx := ContextWithDB(context.Background(), db)
+
+	mock.ExpectPrepare(`^SELECT 1$`)
+	mock.ExpectExec(`^SELECT 1$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^SELECT 1$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectPrepare(`^SELECT 2$`)
+	mock.ExpectExec(`^SELECT 2$`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	for _, query := range []string{"SELECT 1", "SELECT 1", "SELECT 2"} {
+		if _, err := Exec(ctx, query); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStmtCacheEvictInUse(t *testing.T) {
+	t.Parallel()
+
+	db, mock := newMockStmtCacheDB(t, 1)
+	ctx := context.Background()
+
+	mock.ExpectPrepare(`^SELECT 1$`).WillBeClosed()
+	mock.ExpectPrepare(`^SELECT 2$`)
+
+	_, release, err := db.stmts.get(ctx, "SELECT 1", db.DB.PrepareContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, release2, err := db.stmts.get(ctx, "SELECT 2", db.DB.PrepareContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release2()
+
+	if _, _, ok := db.stmts.lookup("SELECT 1"); ok {
+		t.Error("expected SELECT 1 to be evicted")
+	}
+	if err := mock.ExpectationsWereMet(); err == nil {
+		t.Error("expected the evicted statement to stay open while in use")
+	}
+
+	release()
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStmtCacheStalePlan(t *testing.T) {
+	t.Parallel()
+
+	db, mock := newMockStmtCacheDB(t, 2)
+	ctx := ContextWithDB(context.Background(), db)
+
+	stale := &pq.Error{Code: "0A000", Message: "cached plan must not change result type"}
+	mock.ExpectPrepare(`^SELECT \* FROM t$`).WillBeClosed()
+	mock.ExpectQuery(`^SELECT \* FROM t$`).WillReturnError(stale)
+	mock.ExpectPrepare(`^SELECT \* FROM t$`)
+	mock.ExpectQuery(`^SELECT \* FROM t$`).WillReturnRows(sqlmock.NewRows([]string{"a"}).AddRow(1))
+
+	rows, err := Query(ctx, "SELECT * FROM t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rows.Next() {
+		t.Error("expected a row")
+	}
+	rows.Close()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStmtCacheTransaction(t *testing.T) {
+	t.Parallel()
+
+	db, mock := newMockStmtCacheDB(t, 2)
+	ctx := ContextWithDB(context.Background(), db)
+
+	// SELECT 1 is cached on the database and reused in the transaction,
+	// SELECT 2 is prepared once in the transaction.
+	mock.ExpectPrepare(`^SELECT 1$`)
+	mock.ExpectExec(`^SELECT 1$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectBegin()
+	mock.ExpectExec(`^SELECT 1$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^SELECT 1$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectPrepare(`^SELECT 2$`)
+	mock.ExpectExec(`^SELECT 2$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^SELECT 2$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	if _, err := Exec(ctx, "SELECT 1"); err != nil {
+		t.Fatal(err)
+	}
+	err := Atomic(ctx, func(ctx context.Context) error {
+		for _, query := range []string{"SELECT 1", "SELECT 1", "SELECT 2", "SELECT 2"} {
+			if _, err := Exec(ctx, query); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNewStmtCacheDBSize(t *testing.T) {
+	t.Parallel()
+
+	db, _ := newMockStmtCacheDB(t, 0)
+	if db.size != DefaultStmtCacheSize {
+		t.Errorf("expected the default size, got %d", db.size)
+	}
+}
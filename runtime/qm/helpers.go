@@ -0,0 +1,191 @@
+package qm
+
+import (
+	"database/sql/driver"
+)
+
+// WhereHelper builds where clauses for a column, taking arguments of the
+// column Go type. The generated <Model>Where values have one for every column.
+type WhereHelper[T any] struct {
+	column qualifiedColumn
+}
+
+// NewWhereHelper returns a WhereHelper for column of table, which must be already quoted.
+func NewWhereHelper[T any](table, column string) WhereHelper[T] {
+	return WhereHelper[T]{column: qualifiedColumn{table, column}}
+}
+
+// As returns the helper for the column of the table aliased as alias, like the
+// f alias in the loaders of LoadWith. alias is used as is, it's not quoted.
+func (w WhereHelper[T]) As(alias string) WhereHelper[T] {
+	w.column.table = alias
+	return w
+}
+
+// EQ matches rows where the column is equal to x. If x is a null value,
+// it matches rows where the column is NULL.
+func (w WhereHelper[T]) EQ(x T) QueryMod {
+	if isNull(x) {
+		return w.IsNull()
+	}
+	return Where(w.column.String()+" = ?", x)
+}
+
+// NEQ matches rows where the column is not equal to x. If x is a null value,
+// it matches rows where the column is not NULL.
+func (w WhereHelper[T]) NEQ(x T) QueryMod {
+	if isNull(x) {
+		return w.IsNotNull()
+	}
+	return Where(w.column.String()+" <> ?", x)
+}
+
+// LT matches rows where the column is less than x.
+func (w WhereHelper[T]) LT(x T) QueryMod {
+	return Where(w.column.String()+" < ?", x)
+}
+
+// LTE matches rows where the column is less than or equal to x.
+func (w WhereHelper[T]) LTE(x T) QueryMod {
+	return Where(w.column.String()+" <= ?", x)
+}
+
+// GT matches rows where the column is greater than x.
+func (w WhereHelper[T]) GT(x T) QueryMod {
+	return Where(w.column.String()+" > ?", x)
+}
+
+// GTE matches rows where the column is greater than or equal to x.
+func (w WhereHelper[T]) GTE(x T) QueryMod {
+	return Where(w.column.String()+" >= ?", x)
+}
+
+// IN matches rows where the column is one of xs.
+func (w WhereHelper[T]) IN(xs ...T) QueryMod {
+	if len(xs) == 0 {
+		return Where("FALSE")
+	}
+	return WhereIn(w.column.String()+" IN ?", toArgs(xs)...)
+}
+
+// NIN matches rows where the column is none of xs.
+func (w WhereHelper[T]) NIN(xs ...T) QueryMod {
+	if len(xs) == 0 {
+		return Where("TRUE")
+	}
+	return WhereIn(w.column.String()+" NOT IN ?", toArgs(xs)...)
+}
+
+// IsNull matches rows where the column is NULL.
+func (w WhereHelper[T]) IsNull() QueryMod {
+	return Where(w.column.String() + " IS NULL")
+}
+
+// IsNotNull matches rows where the column is not NULL.
+func (w WhereHelper[T]) IsNotNull() QueryMod {
+	return Where(w.column.String() + " IS NOT NULL")
+}
+
+// StringWhereHelper is a WhereHelper for text columns, with pattern matching.
+type StringWhereHelper[T any] struct {
+	WhereHelper[T]
+}
+
+// NewStringWhereHelper returns a StringWhereHelper for column of table, which must be already quoted.
+func NewStringWhereHelper[T any](table, column string) StringWhereHelper[T] {
+	return StringWhereHelper[T]{NewWhereHelper[T](table, column)}
+}
+
+// As returns the helper for the column of the table aliased as alias,
+// see WhereHelper.As.
+func (w StringWhereHelper[T]) As(alias string) StringWhereHelper[T] {
+	return StringWhereHelper[T]{w.WhereHelper.As(alias)}
+}
+
+// Like matches rows where the column matches the LIKE pattern.
+func (w StringWhereHelper[T]) Like(pattern string) QueryMod {
+	return Where(w.column.String()+" LIKE ?", pattern)
+}
+
+// NotLike matches rows where the column doesn't match the LIKE pattern.
+func (w StringWhereHelper[T]) NotLike(pattern string) QueryMod {
+	return Where(w.column.String()+" NOT LIKE ?", pattern)
+}
+
+// ILike matches rows where the column matches the case insensitive ILIKE pattern.
+func (w StringWhereHelper[T]) ILike(pattern string) QueryMod {
+	return Where(w.column.String()+" ILIKE ?", pattern)
+}
+
+// NotILike matches rows where the column doesn't match the case insensitive ILIKE pattern.
+func (w StringWhereHelper[T]) NotILike(pattern string) QueryMod {
+	return Where(w.column.String()+" NOT ILIKE ?", pattern)
+}
+
+// OrderByHelper builds order by clauses for a column. The generated
+// <Model>OrderBy values have one for every column.
+type OrderByHelper struct {
+	column qualifiedColumn
+}
+
+// NewOrderByHelper returns an OrderByHelper for column of table, which must be already quoted.
+func NewOrderByHelper(table, column string) OrderByHelper {
+	return OrderByHelper{column: qualifiedColumn{table, column}}
+}
+
+// As returns the helper for the column of the table aliased as alias,
+// see WhereHelper.As.
+func (o OrderByHelper) As(alias string) OrderByHelper {
+	o.column.table = alias
+	return o
+}
+
+// Asc orders by the column, ascending.
+func (o OrderByHelper) Asc() QueryMod {
+	return OrderBy(o.column.String() + " ASC")
+}
+
+// Desc orders by the column, descending.
+func (o OrderByHelper) Desc() QueryMod {
+	return OrderBy(o.column.String() + " DESC")
+}
+
+// AscNullsFirst orders by the column, ascending, with NULLs before other values.
+func (o OrderByHelper) AscNullsFirst() QueryMod {
+	return OrderBy(o.column.String() + " ASC NULLS FIRST")
+}
+
+// DescNullsLast orders by the column, descending, with NULLs after other values.
+func (o OrderByHelper) DescNullsLast() QueryMod {
+	return OrderBy(o.column.String() + " DESC NULLS LAST")
+}
+
+// qualifiedColumn is a column qualified with its table, so the helpers work
+// in queries joining tables with columns of the same name.
+type qualifiedColumn struct {
+	table  string
+	column string
+}
+
+func (c qualifiedColumn) String() string {
+	return c.table + "." + c.column
+}
+
+func toArgs[T any](xs []T) []interface{} {
+	args := make([]interface{}, len(xs))
+	for i, x := range xs {
+		args[i] = x
+	}
+	return args
+}
+
+// isNull returns true if x is a null value of a nullable type,
+// such as an invalid null.String.
+func isNull(x interface{}) bool {
+	v, ok := x.(driver.Valuer)
+	if !ok {
+		return false
+	}
+	val, err := v.Value()
+	return err == nil && val == nil
+}
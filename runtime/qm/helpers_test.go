@@ -0,0 +1,116 @@
+package qm
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/sqlbunny/sqlbunny/runtime/bunny"
+	"github.com/sqlbunny/sqlbunny/runtime/queries"
+	"github.com/sqlbunny/sqlbunny/types/null"
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+// testHelperMod checks the query built with mod, by executing it against a mock.
+func testHelperMod(t *testing.T, mod QueryMod, query string, args ...driver.Value) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := bunny.ContextWithDB(context.Background(), db)
+
+	q := &queries.Query{}
+	queries.SetDialect(q, &queries.Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	Apply(q, From("t"), mod)
+
+	exp := mock.ExpectExec("^" + regexp.QuoteMeta(query) + "$")
+	if len(args) != 0 {
+		exp.WithArgs(args...)
+	}
+	exp.WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if _, err := q.Exec(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWhereHelper(t *testing.T) {
+	t.Parallel()
+
+	w := NewWhereHelper[int64](`"t"`, `"a"`)
+
+	testHelperMod(t, w.EQ(1), `SELECT * FROM "t" WHERE ("t"."a" = $1);`, int64(1))
+	testHelperMod(t, w.NEQ(1), `SELECT * FROM "t" WHERE ("t"."a" <> $1);`, int64(1))
+	testHelperMod(t, w.LT(1), `SELECT * FROM "t" WHERE ("t"."a" < $1);`, int64(1))
+	testHelperMod(t, w.LTE(1), `SELECT * FROM "t" WHERE ("t"."a" <= $1);`, int64(1))
+	testHelperMod(t, w.GT(1), `SELECT * FROM "t" WHERE ("t"."a" > $1);`, int64(1))
+	testHelperMod(t, w.GTE(1), `SELECT * FROM "t" WHERE ("t"."a" >= $1);`, int64(1))
+	testHelperMod(t, w.IN(1, 2), `SELECT * FROM "t" WHERE "t"."a" IN ($1,$2);`, int64(1), int64(2))
+	testHelperMod(t, w.NIN(1, 2), `SELECT * FROM "t" WHERE "t"."a" NOT IN ($1,$2);`, int64(1), int64(2))
+	testHelperMod(t, w.IsNull(), `SELECT * FROM "t" WHERE ("t"."a" IS NULL);`)
+	testHelperMod(t, w.IsNotNull(), `SELECT * FROM "t" WHERE ("t"."a" IS NOT NULL);`)
+}
+
+func TestWhereHelperEmptyIn(t *testing.T) {
+	t.Parallel()
+
+	w := NewWhereHelper[int64](`"t"`, `"a"`)
+
+	testHelperMod(t, w.IN(), `SELECT * FROM "t" WHERE (FALSE);`)
+	testHelperMod(t, w.NIN(), `SELECT * FROM "t" WHERE (TRUE);`)
+}
+
+func TestWhereHelperNull(t *testing.T) {
+	t.Parallel()
+
+	w := NewWhereHelper[null.String](`"t"`, `"a"`)
+
+	testHelperMod(t, w.EQ(null.String{}), `SELECT * FROM "t" WHERE ("t"."a" IS NULL);`)
+	testHelperMod(t, w.NEQ(null.String{}), `SELECT * FROM "t" WHERE ("t"."a" IS NOT NULL);`)
+	testHelperMod(t, w.EQ(null.StringFrom("x")), `SELECT * FROM "t" WHERE ("t"."a" = $1);`, "x")
+	testHelperMod(t, w.NEQ(null.StringFrom("x")), `SELECT * FROM "t" WHERE ("t"."a" <> $1);`, "x")
+}
+
+func TestStringWhereHelper(t *testing.T) {
+	t.Parallel()
+
+	w := NewStringWhereHelper[string](`"t"`, `"a"`)
+
+	testHelperMod(t, w.EQ("x"), `SELECT * FROM "t" WHERE ("t"."a" = $1);`, "x")
+	testHelperMod(t, w.Like("x%"), `SELECT * FROM "t" WHERE ("t"."a" LIKE $1);`, "x%")
+	testHelperMod(t, w.NotLike("x%"), `SELECT * FROM "t" WHERE ("t"."a" NOT LIKE $1);`, "x%")
+	testHelperMod(t, w.ILike("x%"), `SELECT * FROM "t" WHERE ("t"."a" ILIKE $1);`, "x%")
+	testHelperMod(t, w.NotILike("x%"), `SELECT * FROM "t" WHERE ("t"."a" NOT ILIKE $1);`, "x%")
+}
+
+func TestOrderByHelper(t *testing.T) {
+	t.Parallel()
+
+	o := NewOrderByHelper(`"t"`, `"a"`)
+
+	testHelperMod(t, o.Asc(), `SELECT * FROM "t" ORDER BY "t"."a" ASC;`)
+	testHelperMod(t, o.Desc(), `SELECT * FROM "t" ORDER BY "t"."a" DESC;`)
+	testHelperMod(t, o.AscNullsFirst(), `SELECT * FROM "t" ORDER BY "t"."a" ASC NULLS FIRST;`)
+	testHelperMod(t, o.DescNullsLast(), `SELECT * FROM "t" ORDER BY "t"."a" DESC NULLS LAST;`)
+}
+
+func TestHelperAs(t *testing.T) {
+	t.Parallel()
+
+	w := NewWhereHelper[int64](`"t"`, `"a"`)
+	testHelperMod(t, w.As("f").EQ(1), `SELECT * FROM "t" WHERE (f."a" = $1);`, int64(1))
+	testHelperMod(t, w.EQ(1), `SELECT * FROM "t" WHERE ("t"."a" = $1);`, int64(1))
+
+	s := NewStringWhereHelper[string](`"t"`, `"a"`)
+	testHelperMod(t, s.As("f").Like("x%"), `SELECT * FROM "t" WHERE (f."a" LIKE $1);`, "x%")
+
+	o := NewOrderByHelper(`"t"`, `"a"`)
+	testHelperMod(t, o.As("f").Desc(), `SELECT * FROM "t" ORDER BY f."a" DESC;`)
+}
@@ -12,7 +12,8 @@ func Apply(q *queries.Query, mods ...QueryMod) {
 	}
 }
 
-// SQL allows you to execute a plain SQL statement
+// SQL allows you to execute a plain SQL statement.
+// Arguments can be named, see Where.
 func SQL(sql string, args ...interface{}) QueryMod {
 	return func(q *queries.Query) {
 		queries.SetSQL(q, sql, args...)
@@ -30,6 +31,26 @@ func Load(relationships ...string) QueryMod {
 	}
 }
 
+// LoadWith eager loads a relationship, like Load, applying mods to the query
+// loading it. They're added after the ForeignWhere and ForeignOrderBy of the
+// relationship. In the query the loaded model table is aliased as f, use the As
+// method of the generated where helpers to refer to it.
+//
+// Loads with more than queries.LoadChunkSize key arguments are split in several
+// queries, each one with mods. So Limit and Offset apply to the rows loaded by
+// each query, not to all the loaded rows nor to the rows of each object.
+//
+//	qm.LoadWith("Books", models.BookWhere.Status.As("f").EQ(models.BookStatusPublished), qm.OrderBy("f.created_at desc"))
+func LoadWith(relationship string, mods ...QueryMod) QueryMod {
+	return func(q *queries.Query) {
+		fns := make([]func(*queries.Query), len(mods))
+		for i, mod := range mods {
+			fns[i] = mod
+		}
+		queries.AppendLoadWith(q, relationship, fns...)
+	}
+}
+
 // InnerJoin on another model
 func InnerJoin(clause string, args ...interface{}) QueryMod {
 	return func(q *queries.Query) {
@@ -37,6 +58,45 @@ func InnerJoin(clause string, args ...interface{}) QueryMod {
 	}
 }
 
+// LeftJoin on another model. Columns of the joined model are NULL for rows
+// without a match, so bind them to a nullable struct (see queries.Bind).
+func LeftJoin(clause string, args ...interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendLeftOuterJoin(q, clause, args...)
+	}
+}
+
+// RightJoin on another model
+func RightJoin(clause string, args ...interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendRightOuterJoin(q, clause, args...)
+	}
+}
+
+// FullJoin on another model
+func FullJoin(clause string, args ...interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendFullOuterJoin(q, clause, args...)
+	}
+}
+
+// With adds a common table expression, WITH name AS (query), to the query.
+// name can include a column list, like "t(a, b)". If query is built with
+// queries.Raw, it must use ? placeholders.
+func With(name string, query *queries.Query) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWith(q, name, query)
+	}
+}
+
+// WithRecursive adds a recursive common table expression, WITH RECURSIVE name AS (query),
+// to the query. See With.
+func WithRecursive(name string, query *queries.Query) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWithRecursive(q, name, query)
+	}
+}
+
 // Select specific fields opposed to all fields
 func Select(fields ...string) QueryMod {
 	return func(q *queries.Query) {
@@ -44,7 +104,12 @@ func Select(fields ...string) QueryMod {
 	}
 }
 
-// Where allows you to specify a where clause for your statement
+// Where allows you to specify a where clause for your statement.
+// Arguments can be subqueries, which are inlined in place of their placeholder.
+// They can also be named, as :name or @name, passing a single map[string]any
+// or struct with bunny tags. Array slices like arr[1:n] aren't parameters.
+//
+//	qm.Where("price > :min AND author_id = :author", map[string]any{"min": 10, "author": id})
 func Where(clause string, args ...interface{}) QueryMod {
 	return func(q *queries.Query) {
 		queries.AppendWhere(q, clause, args...)
@@ -53,12 +118,63 @@ func Where(clause string, args ...interface{}) QueryMod {
 
 // WhereIn allows you to specify a "x IN (set)" clause for your where statement
 // Example clauses: "field in ?", "(field1,field2) in ?"
+// The set can also be a subquery, passed as the only argument.
 func WhereIn(clause string, args ...interface{}) QueryMod {
 	return func(q *queries.Query) {
 		queries.AppendIn(q, clause, args...)
 	}
 }
 
+// And groups the where clauses added by mods into a single expression,
+// joining them with AND. Mods other than Where, WhereIn and the
+// expression mods (And, Or, Not, Expr) are ignored.
+//
+// Example: qm.And(qm.Where("a = ?", 1), qm.Or(qm.Where("b = ?", 2), qm.Where("c = ?", 3)))
+// renders as ((a = $1) AND ((b = $2) OR (c = $3))).
+func And(mods ...QueryMod) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereAnd(q, exprQuery(mods))
+	}
+}
+
+// Or groups the where clauses added by mods into a single expression,
+// joining them with OR. See And for the mods it accepts.
+func Or(mods ...QueryMod) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereOr(q, exprQuery(mods))
+	}
+}
+
+// Not negates the where clauses added by mods, joined with AND.
+// See And for the mods it accepts.
+func Not(mods ...QueryMod) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereNot(q, exprQuery(mods))
+	}
+}
+
+// Expr wraps the where clauses added by mods in parentheses. It's the same as And,
+// and reads better when grouping a single Or with other where clauses.
+func Expr(mods ...QueryMod) QueryMod {
+	return And(mods...)
+}
+
+func exprQuery(mods []QueryMod) *queries.Query {
+	q := &queries.Query{}
+	Apply(q, mods...)
+	return q
+}
+
+// WhereExists matches rows for which the subquery returns any row.
+func WhereExists(sub *queries.Query) QueryMod {
+	return Where("EXISTS ?", sub)
+}
+
+// WhereNotExists matches rows for which the subquery returns no rows.
+func WhereNotExists(sub *queries.Query) QueryMod {
+	return Where("NOT EXISTS ?", sub)
+}
+
 // GroupBy allows you to specify a group by clause for your statement
 func GroupBy(clause string) QueryMod {
 	return func(q *queries.Query) {
@@ -73,7 +189,8 @@ func OrderBy(clause string) QueryMod {
 	}
 }
 
-// Having allows you to specify a having clause for your statement
+// Having allows you to specify a having clause for your statement.
+// Arguments can be named, see Where.
 func Having(clause string, args ...interface{}) QueryMod {
 	return func(q *queries.Query) {
 		queries.AppendHaving(q, clause, args...)
@@ -87,6 +204,46 @@ func From(from string) QueryMod {
 	}
 }
 
+// FromSubquery adds a subquery, with the given alias, to the from statements.
+func FromSubquery(sub *queries.Query, alias string) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendFromSubquery(q, sub, alias)
+	}
+}
+
+// Union combines the results of the query with the ones of other, removing duplicates.
+// OrderBy, Limit and Offset apply to the combined results. Set operations are applied
+// in order, each one to the results combined so far.
+func Union(other *queries.Query) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendUnion(q, other)
+	}
+}
+
+// UnionAll combines the results of the query with the ones of other, keeping duplicates.
+// OrderBy, Limit and Offset apply to the combined results.
+func UnionAll(other *queries.Query) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendUnionAll(q, other)
+	}
+}
+
+// Intersect keeps the results of the query that are also returned by other.
+// OrderBy, Limit and Offset apply to the combined results.
+func Intersect(other *queries.Query) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendIntersect(q, other)
+	}
+}
+
+// Except removes the results of the query that are also returned by other.
+// OrderBy, Limit and Offset apply to the combined results.
+func Except(other *queries.Query) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendExcept(q, other)
+	}
+}
+
 // Limit the number of returned rows
 func Limit(limit int) QueryMod {
 	return func(q *queries.Query) {
@@ -0,0 +1 @@
+SELECT "c".* FROM cats c FULL JOIN dogs d on d.cat_id = c.id;
\ No newline at end of file
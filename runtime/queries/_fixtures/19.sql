@@ -0,0 +1 @@
+SELECT "c".* FROM cats c INNER JOIN owners o on o.id = c.owner_id and o.active = $1 LEFT JOIN dogs d on d.cat_id = c.id and d.age > $2 FULL JOIN toys t on t.cat_id = c.id and t.color in ($3, $4) WHERE (c.name = $5);
\ No newline at end of file
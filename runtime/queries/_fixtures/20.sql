@@ -0,0 +1 @@
+SELECT * FROM "t" WHERE ((a = $1) OR (b = $2 and c = $3)) AND (d = $4);
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT * FROM "t" WHERE ((a = $1) AND (("b" IN ($2,$3,$4)) OR (NOT (c = $5)) OR (NOT ((d = $6) AND ((e, f) IN (($7,$8),($9,$10))))))) AND "g" IN ($11,$12);
\ No newline at end of file
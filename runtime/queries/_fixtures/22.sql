@@ -0,0 +1 @@
+SELECT * FROM "t" WHERE (FALSE);
\ No newline at end of file
@@ -0,0 +1 @@
+WITH young AS (SELECT * FROM "people" WHERE (age < $1)), named(id) AS (select id from names where name = $2) SELECT "young".* FROM "young" INNER JOIN named n on n.id = young.id and n.id > $3 WHERE (young.age > $4);
\ No newline at end of file
@@ -0,0 +1 @@
+WITH RECURSIVE tree(id, parent_id) AS (select id, parent_id from nodes where id = $1 union all select n.id, n.parent_id from nodes n join tree t on n.parent_id = t.id) SELECT * FROM "tree";
\ No newline at end of file
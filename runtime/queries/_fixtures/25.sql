@@ -0,0 +1 @@
+WITH old AS (SELECT "id" FROM "people" WHERE "age" IN ($1,$2)) UPDATE "people" SET "retired" = $3 WHERE (id in (select id from old) and name <> $4);
\ No newline at end of file
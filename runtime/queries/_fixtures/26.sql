@@ -0,0 +1 @@
+WITH gone AS (SELECT "id" FROM "people" WHERE (deleted = $1)) DELETE FROM "people" WHERE (id in (select id from gone) and age > $2);
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT * FROM "books" WHERE (price > $1) AND (EXISTS (SELECT * FROM "reviews" WHERE (reviews.book_id = books.id and stars >= $2)) and stock > $3) AND author_id in (SELECT "id" FROM "authors" WHERE (country = $4));
\ No newline at end of file
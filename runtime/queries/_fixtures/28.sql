@@ -0,0 +1 @@
+SELECT "t"."id" as "t.id" FROM (SELECT * FROM "books" WHERE (price > $1)) AS "t" INNER JOIN authors a on a.id = t.author_id and a.age > $2 WHERE ((t.id in (select book_id from sales where year = $3)) OR (t.id = $4));
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT "authors".*, "b".* FROM "authors", (select * from books where id = $1) AS "b" INNER JOIN x on true;
\ No newline at end of file
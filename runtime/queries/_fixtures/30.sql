@@ -0,0 +1 @@
+(SELECT * FROM "books" WHERE (price > $1) UNION (SELECT * FROM "books" WHERE (stock < $2))) EXCEPT (select * from books where id = $3) ORDER BY id LIMIT 10 OFFSET 20;
\ No newline at end of file
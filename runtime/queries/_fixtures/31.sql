@@ -0,0 +1 @@
+(SELECT "author_id" FROM "books" GROUP BY author_id HAVING count(*) > $1 UNION ALL (SELECT "id" FROM "authors" WHERE "country" IN ($2,$3))) INTERSECT (SELECT "id" FROM "authors" LIMIT 5);
\ No newline at end of file
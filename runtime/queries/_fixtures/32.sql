@@ -0,0 +1 @@
+SELECT COUNT(*) FROM (SELECT * FROM "books" WHERE (price > $1) UNION (SELECT * FROM "books" WHERE (stock < $2))) AS "combined";
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT COUNT(*) FROM (SELECT "id" FROM "a" UNION (SELECT "id" FROM "b")) AS "combined";
\ No newline at end of file
@@ -9,10 +9,31 @@ import (
 	"github.com/sqlbunny/sqlbunny/runtime/strmangle"
 )
 
+// LoadChunkSize is the maximum number of key arguments of each query made by the
+// generated Load methods. Larger loads are split in several queries, as Postgres
+// allows at most 65535 parameters per statement.
+var LoadChunkSize = 30000
+
+// LoadChunks splits the key arguments of a load in chunks of at most LoadChunkSize
+// arguments, keeping the group arguments of each key in the same chunk.
+func LoadChunks(args []interface{}, group int) [][]interface{} {
+	return loadChunks(args, group, LoadChunkSize)
+}
+
+func loadChunks(args []interface{}, group int, size int) [][]interface{} {
+	size = max(size/group, 1) * group
+	var chunks [][]interface{}
+	for start := 0; start < len(args); start += size {
+		chunks = append(chunks, args[start:min(start+size, len(args))])
+	}
+	return chunks
+}
+
 type loadRelationshipState struct {
 	ctx    context.Context
 	loaded map[string]struct{}
 	toLoad []string
+	mods   map[string][]func(*Query)
 }
 
 func (l loadRelationshipState) hasLoaded(depth int) bool {
@@ -25,9 +46,14 @@ func (l loadRelationshipState) setLoaded(depth int) {
 }
 
 func (l loadRelationshipState) buildKey(depth int) string {
+	return loadKey(l.toLoad[:depth+1])
+}
+
+// loadKey returns the key of a relationship path, like "Relationship.NestedRelationship".
+func loadKey(path []string) string {
 	buf := strmangle.GetBuffer()
 
-	for i, piece := range l.toLoad[:depth+1] {
+	for i, piece := range path {
 		if i != 0 {
 			buf.WriteByte('.')
 		}
@@ -46,10 +72,12 @@ func (l loadRelationshipState) buildKey(depth int) string {
 // obj should be one of:
 // *[]*struct or *struct
 // bkind should reflect what kind of thing it is above
-func eagerLoad(ctx context.Context, toLoad []string, obj interface{}, bkind bindKind) error {
+// mods are the query mods to pass to the load functions, by relationship key.
+func eagerLoad(ctx context.Context, toLoad []string, mods map[string][]func(*Query), obj interface{}, bkind bindKind) error {
 	state := loadRelationshipState{
 		ctx:    ctx,
 		loaded: map[string]struct{}{},
+		mods:   mods,
 	}
 
 	val := reflect.ValueOf(obj)
@@ -77,12 +105,13 @@ func eagerLoad(ctx context.Context, toLoad []string, obj interface{}, bkind bind
 // loadRelationships dynamically calls the template generated eager load
 // functions of the form:
 //
-//   func (l ModelL) LoadRelationshipName(ctx context.Context, slice []*Model) error
+//   func (l ModelL) LoadRelationshipName(ctx context.Context, slice []*Model, mods ...qm.QueryMod) error
 //
 // The arguments to this function are:
 //   - l is not used, and it is always passed the zero value.
 //   - ctx is used to perform additional queries that might be required for loading the relationships.
 //   - slice is the slice of model instances, always of the type []*Model.
+//   - mods are the query mods given with qm.LoadWith for the relationship.
 //
 // We start with a normal select before eager loading anything: select * from a;
 // Then we start eager loading things, it can be represented by a DAG
@@ -152,6 +181,16 @@ func (l loadRelationshipState) callLoadFunction(depth int, loadingFrom reflect.V
 		reflect.ValueOf(l.ctx),
 		loadingFrom,
 	}
+	if mods := l.mods[l.buildKey(depth)]; len(mods) != 0 {
+		if !loadMethod.Type.IsVariadic() {
+			return errors.Errorf("%s%s method for eager loading does not take query mods", loadMethodPrefix, current)
+		}
+		// Convert the mods to the qm.QueryMod type the method takes.
+		modType := loadMethod.Type.In(loadMethod.Type.NumIn() - 1).Elem()
+		for _, mod := range mods {
+			methodArgs = append(methodArgs, reflect.ValueOf(mod).Convert(modType))
+		}
+	}
 
 	ret := loadMethod.Func.Call(methodArgs)
 	if intf := ret[0].Interface(); intf != nil {
@@ -157,7 +157,7 @@ func TestEagerLoadFromOne(t *testing.T) {
 	obj := &testEager{}
 
 	toLoad := []string{"ChildOne.NestedMany", "ChildOne.NestedOne", "ChildMany.NestedMany", "ChildMany.NestedOne"}
-	err := eagerLoad(context.Background(), toLoad, obj, kindStruct)
+	err := eagerLoad(context.Background(), toLoad, nil, obj, kindStruct)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -199,7 +199,7 @@ func TestEagerLoadFromMany(t *testing.T) {
 	}
 
 	toLoad := []string{"ChildOne.NestedMany", "ChildOne.NestedOne", "ChildMany.NestedMany", "ChildMany.NestedOne"}
-	err := eagerLoad(context.Background(), toLoad, &slice, kindPtrSliceStruct)
+	err := eagerLoad(context.Background(), toLoad, nil, &slice, kindPtrSliceStruct)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -243,7 +243,7 @@ func TestEagerLoadZeroParents(t *testing.T) {
 	obj := &testEager{}
 
 	toLoad := []string{"ZeroMany.NestedMany", "ZeroOne.NestedOne", "ZeroMany.NestedMany", "ZeroOne.NestedOne"}
-	err := eagerLoad(context.Background(), toLoad, obj, kindStruct)
+	err := eagerLoad(context.Background(), toLoad, nil, obj, kindStruct)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -265,7 +265,7 @@ func TestEagerLoadZeroParentsMany(t *testing.T) {
 	}
 
 	toLoad := []string{"ZeroMany.NestedMany", "ZeroOne.NestedOne", "ZeroMany.NestedMany", "ZeroOne.NestedOne"}
-	err := eagerLoad(context.Background(), toLoad, &obj, kindPtrSliceStruct)
+	err := eagerLoad(context.Background(), toLoad, nil, &obj, kindPtrSliceStruct)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -329,3 +329,105 @@ func checkNestedMany(ns []*testEagerNested) {
 		panic(fmt.Sprintf("ns[1] had wrong id: %d", ns[1].ID))
 	}
 }
+
+type testEagerMod func(*Query)
+
+type testEagerMods struct {
+	ID int
+	R  *testEagerModsR
+	L  testEagerModsL
+}
+type testEagerModsR struct {
+	Children []*testEagerChild
+}
+type testEagerModsL struct {
+}
+
+func (testEagerModsL) LoadChildren(_ context.Context, slice []*testEagerMods, mods ...testEagerMod) error {
+	q := &Query{}
+	for _, mod := range mods {
+		mod(q)
+	}
+	for _, o := range slice {
+		o.R = &testEagerModsR{}
+		for _, w := range q.where {
+			o.R.Children = append(o.R.Children, &testEagerChild{ID: w.args[0].(int)})
+		}
+	}
+	return nil
+}
+
+func TestEagerLoadWithMods(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{}
+	AppendLoadWith(q, "children", func(q *Query) { AppendWhere(q, "a = ?", 1) })
+	AppendLoadWith(q, "Children", func(q *Query) { AppendWhere(q, "b = ?", 2) })
+
+	obj := &testEagerMods{}
+	if err := eagerLoad(context.Background(), q.load, q.loadMods, obj, kindStruct); err != nil {
+		t.Fatal(err)
+	}
+	if len(obj.R.Children) != 2 || obj.R.Children[0].ID != 1 || obj.R.Children[1].ID != 2 {
+		t.Errorf("mods were not passed to the load method: %v", obj.R.Children)
+	}
+
+	q = &Query{}
+	AppendLoadWith(q, "ChildOne", func(q *Query) {})
+	if err := eagerLoad(context.Background(), q.load, q.loadMods, &testEager{}, kindStruct); err == nil {
+		t.Error("expected an error passing mods to a load method without them")
+	}
+}
+
+func TestLoadChunks(t *testing.T) {
+	t.Parallel()
+
+	args := func(n int) []interface{} {
+		res := make([]interface{}, n)
+		for i := range res {
+			res[i] = i
+		}
+		return res
+	}
+
+	tests := []struct {
+		args  int
+		group int
+		size  int
+		want  []int
+	}{
+		{args: 0, group: 1, size: 4, want: nil},
+		{args: 4, group: 1, size: 4, want: []int{4}},
+		{args: 5, group: 1, size: 4, want: []int{4, 1}},
+		{args: 8, group: 1, size: 4, want: []int{4, 4}},
+		// The keys of composite keys aren't split, the chunks are rounded down to whole keys.
+		{args: 6, group: 2, size: 5, want: []int{4, 2}},
+		{args: 6, group: 3, size: 5, want: []int{3, 3}},
+		// At least one key per chunk, even if it has more arguments than the chunk size.
+		{args: 6, group: 3, size: 2, want: []int{3, 3}},
+	}
+
+	for i, test := range tests {
+		var got []int
+		next := 0
+		for _, chunk := range loadChunks(args(test.args), test.group, test.size) {
+			got = append(got, len(chunk))
+			for _, a := range chunk {
+				if a != next {
+					t.Errorf("%d) chunks out of order, want %d got %v", i, next, a)
+				}
+				next++
+			}
+		}
+		if fmt.Sprint(got) != fmt.Sprint(test.want) {
+			t.Errorf("%d) want chunks of %v, got %v", i, test.want, got)
+		}
+	}
+
+	if n := len(LoadChunks(args(LoadChunkSize), 1)); n != 1 {
+		t.Errorf("want 1 chunk of LoadChunkSize arguments, got %d", n)
+	}
+	if n := len(LoadChunks(args(LoadChunkSize+1), 1)); n != 2 {
+		t.Errorf("want 2 chunks of LoadChunkSize+1 arguments, got %d", n)
+	}
+}
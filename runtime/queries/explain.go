@@ -0,0 +1,132 @@
+package queries
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/sqlbunny/errors"
+	"github.com/sqlbunny/sqlbunny/runtime/bunny"
+)
+
+// ExplainOptions sets the options of EXPLAIN.
+type ExplainOptions struct {
+	// Analyze executes the query to report the actual times and row counts.
+	// Keep in mind the statement is really run, so an UPDATE or DELETE
+	// modifies the database unless it's wrapped in a transaction rolled back afterwards.
+	Analyze bool
+	// Buffers reports the buffer usage. It requires Analyze before Postgres 13.
+	Buffers bool
+}
+
+// Plan is a node of a query plan tree.
+type Plan struct {
+	NodeType     string  `json:"Node Type"`
+	RelationName string  `json:"Relation Name,omitempty"`
+	Alias        string  `json:"Alias,omitempty"`
+	IndexName    string  `json:"Index Name,omitempty"`
+	StartupCost  float64 `json:"Startup Cost"`
+	TotalCost    float64 `json:"Total Cost"`
+	PlanRows     float64 `json:"Plan Rows"`
+	PlanWidth    int     `json:"Plan Width"`
+
+	// Set with ExplainOptions.Analyze.
+	ActualStartupTime float64 `json:"Actual Startup Time,omitempty"`
+	ActualTotalTime   float64 `json:"Actual Total Time,omitempty"`
+	ActualRows        float64 `json:"Actual Rows,omitempty"`
+	ActualLoops       float64 `json:"Actual Loops,omitempty"`
+
+	// Set with ExplainOptions.Buffers.
+	SharedHitBlocks  int64 `json:"Shared Hit Blocks,omitempty"`
+	SharedReadBlocks int64 `json:"Shared Read Blocks,omitempty"`
+
+	Plans []*Plan `json:"Plans,omitempty"`
+}
+
+// Walk calls fn for the node and all its descendants, depth first.
+func (p *Plan) Walk(fn func(*Plan)) {
+	fn(p)
+	for _, c := range p.Plans {
+		c.Walk(fn)
+	}
+}
+
+// Explanation is the result of Explain.
+type Explanation struct {
+	// Query and Args are the SQL and arguments the query runs with.
+	Query string
+	Args  []interface{}
+
+	Plan *Plan
+	// PlanningTime and ExecutionTime are in milliseconds. ExecutionTime is
+	// only set with ExplainOptions.Analyze.
+	PlanningTime  float64
+	ExecutionTime float64
+}
+
+// TotalCost returns the estimated total cost of the query.
+func (e *Explanation) TotalCost() float64 {
+	return e.Plan.TotalCost
+}
+
+// NodeTypes returns the node types of the plan, depth first.
+func (e *Explanation) NodeTypes() []string {
+	var res []string
+	e.Plan.Walk(func(p *Plan) {
+		res = append(res, p.NodeType)
+	})
+	return res
+}
+
+// SeqScans returns the nodes of the plan scanning a table sequentially.
+func (e *Explanation) SeqScans() []*Plan {
+	var res []*Plan
+	e.Plan.Walk(func(p *Plan) {
+		if p.NodeType == "Seq Scan" {
+			res = append(res, p)
+		}
+	})
+	return res
+}
+
+type explainResult struct {
+	Plan          *Plan   `json:"Plan"`
+	PlanningTime  float64 `json:"Planning Time"`
+	ExecutionTime float64 `json:"Execution Time"`
+}
+
+// Explain runs EXPLAIN on the exact SQL and arguments the query would run with,
+// and returns the parsed plan.
+func (q *Query) Explain(ctx context.Context, opts ExplainOptions) (*Explanation, error) {
+	query, args := buildQuery(q)
+
+	options := []string{"FORMAT JSON"}
+	if opts.Analyze {
+		options = append(options, "ANALYZE")
+	}
+	if opts.Buffers {
+		options = append(options, "BUFFERS")
+	}
+
+	var out []byte
+	err := bunny.QueryRow(ctx, "EXPLAIN ("+strings.Join(options, ", ")+") "+query, args...).Scan(&out)
+	if err != nil {
+		return nil, errors.Errorf("explain: %w", err)
+	}
+
+	var res []explainResult
+	if err := json.Unmarshal(out, &res); err != nil {
+		return nil, errors.Errorf("explain: failed to parse plan: %w", err)
+	}
+	if len(res) != 1 || res[0].Plan == nil {
+		return nil, errors.New("explain: expected one plan")
+	}
+
+	return &Explanation{
+		Query:         query,
+		Args:          args,
+		Plan:          res[0].Plan,
+		PlanningTime:  res[0].PlanningTime,
+		ExecutionTime: res[0].ExecutionTime,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package queries
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	query := &Query{
+		from:    []string{"books"},
+		where:   []where{{clause: "author_id = ?", args: []interface{}{5}}},
+		dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan := `[{"Plan": {"Node Type": "Hash Join", "Total Cost": 12.5, "Plans": [
+		{"Node Type": "Seq Scan", "Relation Name": "books", "Total Cost": 4},
+		{"Node Type": "Index Scan", "Relation Name": "authors", "Index Name": "authors_pkey", "Total Cost": 8}
+	]}, "Planning Time": 0.1, "Execution Time": 0.5}]`
+	ret := sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow([]byte(plan))
+	mock.ExpectQuery(`^EXPLAIN \(FORMAT JSON, ANALYZE\) SELECT \* FROM "books" WHERE \(author_id = \$1\);$`).WithArgs(5).WillReturnRows(ret)
+
+	e, err := query.Explain(dbToContext(db), ExplainOptions{Analyze: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Query != `SELECT * FROM "books" WHERE (author_id = $1);` || !reflect.DeepEqual(e.Args, []interface{}{5}) {
+		t.Errorf("Got invalid query: %s %v", e.Query, e.Args)
+	}
+	if e.TotalCost() != 12.5 || e.ExecutionTime != 0.5 {
+		t.Errorf("Got invalid costs: %v %v", e.TotalCost(), e.ExecutionTime)
+	}
+	if nodes := e.NodeTypes(); !reflect.DeepEqual(nodes, []string{"Hash Join", "Seq Scan", "Index Scan"}) {
+		t.Errorf("Got invalid node types: %v", nodes)
+	}
+	if scans := e.SeqScans(); len(scans) != 1 || scans[0].RelationName != "books" {
+		t.Errorf("Got invalid seq scans: %v", scans)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,165 @@
+package queries
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/sqlbunny/sqlbunny/runtime/strmangle"
+)
+
+// namedArgs rewrites the :name and @name parameters of clause into ? placeholders when
+// args is a single map with string keys, or a struct with bunny tags, and returns the
+// clause with the matching positional arguments. A parameter used twice gets its
+// argument twice. In named clauses, bare question marks are escaped, so they're
+// left as is, like the ? jsonb operator. Colons of array slices, like arr[1:n],
+// aren't parameters, so use @name for a parameter right after a [.
+//
+// Otherwise, or if the clause has no named parameters, clause and args are returned
+// unchanged. It panics if an argument is missing.
+func namedArgs(clause string, args []interface{}) (string, []interface{}) {
+	if len(args) != 1 {
+		return clause, args
+	}
+	lookup := namedArgLookup(args[0])
+	if lookup == nil {
+		return clause, args
+	}
+
+	buf := strmangle.GetBuffer()
+	defer strmangle.PutBuffer(buf)
+	var res []interface{}
+
+	var quote byte
+	brackets := 0
+	for i := 0; i < len(clause); i++ {
+		c := clause[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			brackets++
+		case c == ']' && brackets > 0:
+			brackets--
+		case c == ':' && isSliceColon(clause, i, brackets):
+			// Written as is.
+		case c == '\\' && i+1 < len(clause) && clause[i+1] == '?':
+			buf.WriteString(`\?`)
+			i++
+			continue
+		case c == '?':
+			buf.WriteString(`\?`)
+			continue
+		case (c == ':' || c == '@') && (i == 0 || clause[i-1] != c) && i+1 < len(clause) && isNameStart(clause[i+1]):
+			end := i + 2
+			for end < len(clause) && isNamePart(clause[end]) {
+				end++
+			}
+			name := clause[i+1 : end]
+			v, ok := lookup(name)
+			if !ok {
+				panic(fmt.Sprintf("sqlbunny: missing named argument %q in %q", name, clause))
+			}
+			buf.WriteByte('?')
+			res = append(res, v)
+			i = end - 1
+			continue
+		}
+		buf.WriteByte(c)
+	}
+
+	if res == nil {
+		return clause, args
+	}
+	return buf.String(), res
+}
+
+// isSliceColon returns true if the colon at i of clause separates the bounds of an
+// array slice, like arr[1:n] or arr[:n], instead of starting a named parameter.
+func isSliceColon(clause string, i int, brackets int) bool {
+	if i == 0 {
+		return false
+	}
+	prev := clause[i-1]
+	if prev == '[' || (prev >= '0' && prev <= '9') {
+		return true
+	}
+	// After the lower bound expression, like arr[lo:hi] or arr[f(x):n].
+	return brackets > 0 && (isNamePart(prev) || prev == ')' || prev == ']')
+}
+
+// newRawSQL returns the raw query with its named arguments, if any, replaced by
+// ? placeholders, which are converted when the query is built. See rawSQL.build.
+func newRawSQL(query string, args []interface{}) rawSQL {
+	named, res := namedArgs(query, args)
+	if named == query {
+		return rawSQL{sql: query, args: args}
+	}
+	return rawSQL{sql: named, args: res, named: true}
+}
+
+// build returns the raw query, numbering the placeholders of named arguments if
+// the dialect uses indexed placeholders, or if it's not set. Otherwise the question
+// marks escaped by namedArgs are unescaped.
+func (r rawSQL) build(dialect *Dialect) string {
+	if !r.named {
+		return r.sql
+	}
+	if dialect != nil && !dialect.IndexPlaceholders {
+		return strings.ReplaceAll(r.sql, `\?`, "?")
+	}
+	sql, _ := convertQuestionMarks(r.sql, 1)
+	return sql
+}
+
+// namedArgLookup returns a function to look up the named arguments in arg, or nil
+// if arg is not a map with string keys or a struct.
+func namedArgLookup(arg interface{}) func(name string) (interface{}, bool) {
+	switch arg.(type) {
+	case nil, driver.Valuer, subquery:
+		return nil
+	}
+
+	val := reflect.ValueOf(arg)
+	if val.Kind() == reflect.Ptr && val.Elem().Kind() == reflect.Struct {
+		val = val.Elem()
+	}
+
+	switch {
+	case val.Kind() == reflect.Map && val.Type().Key().Kind() == reflect.String:
+		return func(name string) (interface{}, bool) {
+			v := val.MapIndex(reflect.ValueOf(name).Convert(val.Type().Key()))
+			if !v.IsValid() {
+				return nil, false
+			}
+			return v.Interface(), true
+		}
+	case val.Kind() == reflect.Struct:
+		mapping := MakeStructMapping(val.Type())
+		if len(mapping) == 0 {
+			// Not meant for named arguments, like time.Time.
+			return nil
+		}
+		return func(name string) (interface{}, bool) {
+			m, ok := mapping[name]
+			if !ok {
+				return nil, false
+			}
+			return ValuesFromMapping(val, []MappedField{m})[0], true
+		}
+	}
+	return nil
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNamePart(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,129 @@
+package queries
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNamedArgs(t *testing.T) {
+	t.Parallel()
+
+	type params struct {
+		Min    int    `bunny:"min"`
+		Author string `bunny:"author"`
+		Other  int
+	}
+
+	now := time.Now()
+	tests := []struct {
+		clause  string
+		args    []interface{}
+		out     string
+		outArgs []interface{}
+	}{
+		{"a = ? AND b = ?", []interface{}{1, 2}, "a = ? AND b = ?", []interface{}{1, 2}},
+		{"a > ?", []interface{}{now}, "a > ?", []interface{}{now}},
+		{"a > ? AND b = 'x:y'", []interface{}{now}, "a > ? AND b = 'x:y'", []interface{}{now}},
+		{"a = :a", []interface{}{5}, "a = :a", []interface{}{5}},
+		{
+			"a > :min AND (b = @author OR c = :author)",
+			[]interface{}{map[string]interface{}{"min": 1, "author": "x"}},
+			"a > ? AND (b = ? OR c = ?)",
+			[]interface{}{1, "x", "x"},
+		},
+		{
+			"a::int > :min AND b = ':min' AND data ? 'k' AND c \\? d AND e = :author",
+			[]interface{}{params{Min: 3, Author: "y"}},
+			"a::int > ? AND b = ':min' AND data \\? 'k' AND c \\? d AND e = ?",
+			[]interface{}{3, "y"},
+		},
+		{"a > :min", []interface{}{&params{Min: 4}}, "a > ?", []interface{}{4}},
+		{
+			"arr[1:n] = :min AND arr[:n] = arr[lo:hi] AND arr[f(x):2] = ANY(ARRAY[@min, :author])",
+			[]interface{}{params{Min: 5, Author: "z"}},
+			"arr[1:n] = ? AND arr[:n] = arr[lo:hi] AND arr[f(x):2] = ANY(ARRAY[?, ?])",
+			[]interface{}{5, 5, "z"},
+		},
+		{"a = 1", []interface{}{map[string]interface{}{}}, "a = 1", []interface{}{map[string]interface{}{}}},
+	}
+
+	for i, test := range tests {
+		out, outArgs := namedArgs(test.clause, test.args)
+		if out != test.out {
+			t.Errorf("%d) Mismatch between expect and got:\n%s\n%s\n", i, test.out, out)
+		}
+		if !reflect.DeepEqual(outArgs, test.outArgs) {
+			t.Errorf("%d) Mismatch between expected args:\n%#v\n%#v\n", i, test.outArgs, outArgs)
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a panic for a missing argument")
+			}
+		}()
+		namedArgs("a = :missing", []interface{}{map[string]interface{}{}})
+	}()
+}
+
+func TestNamedArgsQuery(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{from: []string{"t"}, dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}}
+	AppendWhere(q, "a = ?", 1)
+	AppendWhere(q, "b = :b OR c = :b", map[string]interface{}{"b": 2})
+	AppendGroupBy(q, "d")
+	AppendHaving(q, "count(*) > @n", map[string]interface{}{"n": 3})
+
+	sql, args := buildQuery(q)
+	expect := `SELECT * FROM "t" WHERE (a = $1) AND (b = $2 OR c = $3) GROUP BY d HAVING count(*) > $4;`
+	if sql != expect {
+		t.Errorf("Mismatch between expect and got:\n%s\n%s\n", expect, sql)
+	}
+	if !reflect.DeepEqual(args, []interface{}{1, 2, 2, 3}) {
+		t.Errorf("Got invalid args: %v", args)
+	}
+
+	raw := Raw("SELECT * FROM t WHERE a = :a AND b ? 'k' AND c = :a", map[string]interface{}{"a": 1})
+	sql, args = buildQuery(raw)
+	if expect := "SELECT * FROM t WHERE a = $1 AND b ? 'k' AND c = $2"; sql != expect {
+		t.Errorf("Mismatch between expect and got:\n%s\n%s\n", expect, sql)
+	}
+	if !reflect.DeepEqual(args, []interface{}{1, 1}) {
+		t.Errorf("Got invalid args: %v", args)
+	}
+}
+
+func TestNamedArgsRawDialect(t *testing.T) {
+	t.Parallel()
+
+	raw := Raw("SELECT * FROM t WHERE a = :a AND b = :b", map[string]interface{}{"a": 1, "b": 2})
+	SetDialect(raw, &Dialect{LQ: '`', RQ: '`'})
+	sql, args := buildQuery(raw)
+	if expect := "SELECT * FROM t WHERE a = ? AND b = ?"; sql != expect {
+		t.Errorf("Mismatch between expect and got:\n%s\n%s\n", expect, sql)
+	}
+	if !reflect.DeepEqual(args, []interface{}{1, 2}) {
+		t.Errorf("Got invalid args: %v", args)
+	}
+
+	raw = Raw("SELECT * FROM t WHERE a = :a AND b ? 'k'", map[string]interface{}{"a": 1})
+	SetDialect(raw, &Dialect{LQ: '`', RQ: '`'})
+	if sql, _ := buildQuery(raw); sql != "SELECT * FROM t WHERE a = ? AND b ? 'k'" {
+		t.Errorf("escaped question marks were not unescaped: %s", sql)
+	}
+
+	sub := Raw("SELECT id FROM u WHERE b = :b", map[string]interface{}{"b": 2})
+	q := &Query{from: []string{"t"}, dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}}
+	AppendWhere(q, "a = ?", 1)
+	AppendWhere(q, "id IN ?", sub)
+	sql, args = buildQuery(q)
+	if expect := `SELECT * FROM "t" WHERE (a = $1) AND (id IN (SELECT id FROM u WHERE b = $2));`; sql != expect {
+		t.Errorf("Mismatch between expect and got:\n%s\n%s\n", expect, sql)
+	}
+	if !reflect.DeepEqual(args, []interface{}{1, 2}) {
+		t.Errorf("Got invalid args: %v", args)
+	}
+}
@@ -0,0 +1,160 @@
+package queries
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/sqlbunny/errors"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor is malformed or
+// has been tampered with.
+var ErrInvalidCursor = errors.New("sqlbunny: invalid pagination cursor")
+
+// Page holds the cursors around a page of results fetched with keyset pagination.
+type Page struct {
+	// Next is the cursor of the following page. It's empty on the last page.
+	Next string
+	// Prev is the cursor of the preceding page. It's empty on the first page.
+	Prev string
+}
+
+var (
+	cursorKeyMut sync.RWMutex
+	cursorKey    []byte
+)
+
+// SetCursorKey sets the key pagination cursors are signed with. If it's not set,
+// a random key is used, so cursors are only valid within the process that made them.
+func SetCursorKey(key []byte) {
+	cursorKeyMut.Lock()
+	defer cursorKeyMut.Unlock()
+	cursorKey = key
+}
+
+func getCursorKey() []byte {
+	cursorKeyMut.RLock()
+	key := cursorKey
+	cursorKeyMut.RUnlock()
+	if key != nil {
+		return key
+	}
+
+	cursorKeyMut.Lock()
+	defer cursorKeyMut.Unlock()
+	if cursorKey == nil {
+		cursorKey = make([]byte, 32)
+		if _, err := rand.Read(cursorKey); err != nil {
+			panic(fmt.Sprintf("sqlbunny: failed to generate cursor key: %v", err))
+		}
+	}
+	return cursorKey
+}
+
+type cursor struct {
+	Backward bool              `json:"b,omitempty"`
+	Values   []json.RawMessage `json:"v"`
+}
+
+func signCursor(payload string) string {
+	mac := hmac.New(sha256.New, getCursorKey())
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// EncodeCursor returns a signed cursor pointing at the row with the given key values.
+// If backward is set, the cursor fetches the rows before it instead of after it.
+func EncodeCursor(backward bool, values ...interface{}) (string, error) {
+	c := cursor{
+		Backward: backward,
+		Values:   make([]json.RawMessage, len(values)),
+	}
+	for i, v := range values {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", errors.Errorf("failed to encode cursor value: %w", err)
+		}
+		c.Values[i] = b
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", errors.Errorf("failed to encode cursor: %w", err)
+	}
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	return payload + "." + signCursor(payload), nil
+}
+
+// DecodeCursor checks the signature of a cursor made by EncodeCursor and stores its
+// key values into ptrs. It returns whether the cursor fetches the rows before the key.
+func DecodeCursor(s string, ptrs ...interface{}) (backward bool, err error) {
+	payload, sig, ok := strings.Cut(s, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(signCursor(payload))) {
+		return false, ErrInvalidCursor
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return false, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil || len(c.Values) != len(ptrs) {
+		return false, ErrInvalidCursor
+	}
+	for i, v := range c.Values {
+		if err := json.Unmarshal(v, ptrs[i]); err != nil {
+			return false, errors.Errorf("%w: %v", ErrInvalidCursor, err)
+		}
+	}
+	return c.Backward, nil
+}
+
+// SetKeyset replaces the ordering of the query with the given key columns, and if
+// values is not nil, restricts it to the rows after the key, or before it if backward
+// is set. Rows before the key are returned in descending order.
+func SetKeyset(q *Query, cols []string, values []interface{}, backward bool) {
+	op, dir := ">", "ASC"
+	if backward {
+		op, dir = "<", "DESC"
+	}
+
+	q.touch()
+	q.orderBy = nil
+	for _, c := range cols {
+		q.orderBy = append(q.orderBy, c+" "+dir)
+	}
+
+	if values == nil {
+		return
+	}
+	if len(cols) == 1 {
+		AppendWhere(q, cols[0]+" "+op+" ?", values...)
+		return
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ")
+	AppendWhere(q, fmt.Sprintf("(%s) %s (%s)", strings.Join(cols, ", "), op, placeholders), values...)
+}
+
+// NewPage returns the cursors around a page, given the key values of its first and
+// last rows. hasPrev and hasNext tell whether there are rows before and after the page.
+func NewPage(first, last []interface{}, hasPrev, hasNext bool) (*Page, error) {
+	var err error
+	page := &Page{}
+	if hasPrev {
+		if page.Prev, err = EncodeCursor(true, first...); err != nil {
+			return nil, err
+		}
+	}
+	if hasNext {
+		if page.Next, err = EncodeCursor(false, last...); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
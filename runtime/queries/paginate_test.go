@@ -0,0 +1,88 @@
+package queries
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sqlbunny/errors"
+)
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+
+	c, err := EncodeCursor(true, "abc", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var s string
+	var i int
+	backward, err := DecodeCursor(c, &s, &i)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !backward || s != "abc" || i != 5 {
+		t.Errorf("Expected true, abc, 5, got %v, %s, %d", backward, s, i)
+	}
+
+	bad := []string{
+		"",
+		"abc",
+		c + "x",
+		"x" + c,
+	}
+	for _, b := range bad {
+		if _, err := DecodeCursor(b, &s, &i); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor for %q, got %v", b, err)
+		}
+	}
+
+	if _, err := DecodeCursor(c, &s); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor for wrong value count, got %v", err)
+	}
+}
+
+func TestSetKeyset(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{orderBy: []string{"name"}}
+	SetKeyset(q, []string{"a", "b"}, []interface{}{1, 2}, false)
+
+	if !reflect.DeepEqual(q.orderBy, []string{"a ASC", "b ASC"}) {
+		t.Errorf("Got invalid order by: %v", q.orderBy)
+	}
+	if len(q.where) != 1 || q.where[0].clause != "(a, b) > (?, ?)" {
+		t.Errorf("Got invalid where: %v", q.where)
+	}
+
+	q = &Query{}
+	SetKeyset(q, []string{"a"}, []interface{}{1}, true)
+
+	if !reflect.DeepEqual(q.orderBy, []string{"a DESC"}) {
+		t.Errorf("Got invalid order by: %v", q.orderBy)
+	}
+	if len(q.where) != 1 || q.where[0].clause != "a < ?" {
+		t.Errorf("Got invalid where: %v", q.where)
+	}
+
+	q = &Query{}
+	SetKeyset(q, []string{"a"}, nil, false)
+	if len(q.where) != 0 {
+		t.Errorf("Expected no where clauses, got %v", q.where)
+	}
+}
+
+func TestSetKeysetBuilt(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{from: []string{"t"}, orderBy: []string{"name"}}
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	if sql, _ := buildQuery(q); sql != `SELECT * FROM "t" ORDER BY name;` {
+		t.Fatalf("Got invalid query: %s", sql)
+	}
+
+	SetKeyset(q, []string{"id"}, nil, false)
+	if sql, _ := buildQuery(q); sql != `SELECT * FROM "t" ORDER BY id ASC;` {
+		t.Errorf("Got invalid query after SetKeyset: %s", sql)
+	}
+}
@@ -3,8 +3,14 @@ package queries
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
 
 	"github.com/sqlbunny/sqlbunny/runtime/bunny"
+	"github.com/sqlbunny/sqlbunny/runtime/strmangle"
 )
 
 // joinKind is the type of join
@@ -16,13 +22,31 @@ const (
 	JoinOuterLeft
 	JoinOuterRight
 	JoinNatural
+	JoinOuterFull
 )
 
+func (k joinKind) String() string {
+	switch k {
+	case JoinInner:
+		return "INNER JOIN"
+	case JoinOuterLeft:
+		return "LEFT JOIN"
+	case JoinOuterRight:
+		return "RIGHT JOIN"
+	case JoinNatural:
+		return "NATURAL JOIN"
+	case JoinOuterFull:
+		return "FULL JOIN"
+	}
+	panic(fmt.Sprintf("unknown join kind %d", int(k)))
+}
+
 // Query holds the state for the built up query
 type Query struct {
 	dialect    *Dialect
 	rawSQL     rawSQL
 	load       []string
+	loadMods   map[string][]func(*Query)
 	delete     bool
 	update     map[string]interface{}
 	selectCols []string
@@ -37,6 +61,107 @@ type Query struct {
 	limit      int
 	offset     int
 	forlock    string
+
+	with          []with
+	withRecursive bool
+
+	fromSubqueries []fromSubquery
+	combinations   []combination
+
+	// version is the queryVersion of the last change to the query.
+	version uint64
+	// built caches the *builtQuery with the SQL built from the query.
+	built atomic.Value
+}
+
+// queryVersion numbers the changes to queries, to tell when their built SQL is stale.
+var queryVersion atomic.Uint64
+
+type builtQuery struct {
+	// version is the stateVersion of the query the SQL was built from.
+	version uint64
+	sql     string
+	args    []interface{}
+}
+
+// touch records a change to the query, invalidating its built SQL.
+func (q *Query) touch() {
+	q.version = queryVersion.Add(1)
+}
+
+// stateVersion returns the version of the latest change to the query or to its subqueries.
+func stateVersion(q *Query) uint64 {
+	v := q.version
+	max := func(sub *Query) {
+		if sv := stateVersion(sub); sv > v {
+			v = sv
+		}
+	}
+	maxArgs := func(args []interface{}) {
+		for _, a := range args {
+			if sub, ok := a.(subquery); ok {
+				max(sub.subquery())
+			}
+		}
+	}
+	var maxWhere func(ws []where)
+	maxWhere = func(ws []where) {
+		for _, w := range ws {
+			maxArgs(w.args)
+			maxWhere(w.children)
+		}
+	}
+
+	maxWhere(q.where)
+	for _, x := range q.in {
+		maxArgs(x.args)
+	}
+	for _, x := range q.joins {
+		maxArgs(x.args)
+	}
+	for _, x := range q.having {
+		maxArgs(x.args)
+	}
+	for _, x := range q.with {
+		max(x.query)
+	}
+	for _, x := range q.fromSubqueries {
+		max(x.query)
+	}
+	for _, x := range q.combinations {
+		max(x.query)
+	}
+	return v
+}
+
+// Clone returns a copy of the query that can be modified without changing q,
+// to use q as a base for other queries. Subqueries are shared.
+func (q *Query) Clone() *Query {
+	return &Query{
+		dialect:        q.dialect,
+		rawSQL:         rawSQL{sql: q.rawSQL.sql, args: slices.Clip(q.rawSQL.args), named: q.rawSQL.named},
+		load:           slices.Clip(q.load),
+		loadMods:       maps.Clone(q.loadMods),
+		delete:         q.delete,
+		update:         maps.Clone(q.update),
+		selectCols:     slices.Clip(q.selectCols),
+		count:          q.count,
+		from:           slices.Clip(q.from),
+		joins:          slices.Clip(q.joins),
+		where:          slices.Clip(q.where),
+		in:             slices.Clip(q.in),
+		groupBy:        slices.Clip(q.groupBy),
+		orderBy:        slices.Clip(q.orderBy),
+		having:         slices.Clip(q.having),
+		limit:          q.limit,
+		offset:         q.offset,
+		forlock:        q.forlock,
+		with:           slices.Clip(q.with),
+		withRecursive:  q.withRecursive,
+		fromSubqueries: slices.Clip(q.fromSubqueries),
+		combinations:   slices.Clip(q.combinations),
+		version:        q.version,
+	}
 }
 
 // Dialect holds values that direct the query builder
@@ -56,9 +181,24 @@ type Dialect struct {
 	UseTopClause bool
 }
 
+// exprKind is the kind of a where expression.
+type exprKind int
+
+const (
+	exprClause exprKind = iota
+	exprIn
+	exprAnd
+	exprOr
+	exprNot
+)
+
 type where struct {
+	kind   exprKind
 	clause string
 	args   []interface{}
+
+	// children of an exprAnd, exprOr or exprNot expression.
+	children []where
 }
 
 type in struct {
@@ -74,6 +214,48 @@ type having struct {
 type rawSQL struct {
 	sql  string
 	args []interface{}
+	// named is set if sql had named arguments, replaced with ? placeholders
+	// to convert for the dialect when the query is built.
+	named bool
+}
+
+type with struct {
+	name  string
+	query *Query
+}
+
+// setOp is a set operation combining the results of two queries.
+type setOp int
+
+const (
+	setOpUnion setOp = iota
+	setOpUnionAll
+	setOpIntersect
+	setOpExcept
+)
+
+func (o setOp) String() string {
+	switch o {
+	case setOpUnion:
+		return "UNION"
+	case setOpUnionAll:
+		return "UNION ALL"
+	case setOpIntersect:
+		return "INTERSECT"
+	case setOpExcept:
+		return "EXCEPT"
+	}
+	panic(fmt.Sprintf("unknown set operation %d", int(o)))
+}
+
+type combination struct {
+	op    setOp
+	query *Query
+}
+
+type fromSubquery struct {
+	query *Query
+	alias string
 }
 
 type join struct {
@@ -82,13 +264,11 @@ type join struct {
 	args   []interface{}
 }
 
-// Raw makes a raw query, usually for use with bind
+// Raw makes a raw query, usually for use with bind.
+// Arguments can be named, see AppendWhere.
 func Raw(query string, args ...interface{}) *Query {
 	return &Query{
-		rawSQL: rawSQL{
-			sql:  query,
-			args: args,
-		},
+		rawSQL: newRawSQL(query, args),
 	}
 }
 
@@ -112,26 +292,48 @@ func (q *Query) Query(ctx context.Context) (*sql.Rows, error) {
 
 // SetDialect on the query.
 func SetDialect(q *Query, dialect *Dialect) {
+	q.touch()
 	q.dialect = dialect
 }
 
-// SetSQL on the query.
+// SetSQL on the query. Arguments can be named, see AppendWhere.
 func SetSQL(q *Query, sql string, args ...interface{}) {
-	q.rawSQL = rawSQL{sql: sql, args: args}
+	q.touch()
+	q.rawSQL = newRawSQL(sql, args)
 }
 
 // SetLoad on the query.
 func SetLoad(q *Query, relationships ...string) {
+	q.touch()
 	q.load = append([]string(nil), relationships...)
+	q.loadMods = nil
 }
 
 // AppendLoad on the query.
 func AppendLoad(q *Query, relationships ...string) {
+	q.touch()
 	q.load = append(q.load, relationships...)
 }
 
+// AppendLoadWith on the query, loading the relationship with the
+// query mods applied to the query of its Load method.
+func AppendLoadWith(q *Query, relationship string, mods ...func(*Query)) {
+	q.touch()
+	q.load = append(q.load, relationship)
+	if q.loadMods == nil {
+		q.loadMods = make(map[string][]func(*Query))
+	}
+	path := strings.Split(relationship, ".")
+	for i := range path {
+		path[i] = strmangle.TitleCase(path[i])
+	}
+	key := loadKey(path)
+	q.loadMods[key] = append(slices.Clip(q.loadMods[key]), mods...)
+}
+
 // SetSelect on the query.
 func SetSelect(q *Query, sel []string) {
+	q.touch()
 	q.selectCols = sel
 }
 
@@ -140,77 +342,206 @@ func GetSelect(q *Query) []string {
 	return q.selectCols
 }
 
+// HasCombinations returns true if the query has set operations, like UNION.
+func HasCombinations(q *Query) bool {
+	return len(q.combinations) != 0
+}
+
+// Select returns a copy of the query selecting the given columns, so it can be used
+// as a subquery argument, like qm.WhereIn("author_id in ?", Authors(mods...).Select("id")).
+// The query itself is left unchanged.
+func (q *Query) Select(cols ...string) *Query {
+	c := q.Clone()
+	c.touch()
+	c.selectCols = append([]string(nil), cols...)
+	return c
+}
+
 // SetCount on the query.
 func SetCount(q *Query) {
+	q.touch()
 	q.count = true
 }
 
 // SetDelete on the query.
 func SetDelete(q *Query) {
+	q.touch()
 	q.delete = true
 }
 
 // SetLimit on the query.
 func SetLimit(q *Query, limit int) {
+	q.touch()
 	q.limit = limit
 }
 
 // SetOffset on the query.
 func SetOffset(q *Query, offset int) {
+	q.touch()
 	q.offset = offset
 }
 
 // SetFor on the query.
 func SetFor(q *Query, clause string) {
+	q.touch()
 	q.forlock = clause
 }
 
 // SetUpdate on the query.
 func SetUpdate(q *Query, cols map[string]interface{}) {
+	q.touch()
 	q.update = cols
 }
 
 // AppendSelect on the query.
 func AppendSelect(q *Query, fields ...string) {
+	q.touch()
 	q.selectCols = append(q.selectCols, fields...)
 }
 
 // AppendFrom on the query.
 func AppendFrom(q *Query, from ...string) {
+	q.touch()
 	q.from = append(q.from, from...)
 }
 
+// AppendFromSubquery adds a subquery, with the given alias, to the from statements.
+func AppendFromSubquery(q *Query, query *Query, alias string) {
+	q.touch()
+	q.fromSubqueries = append(q.fromSubqueries, fromSubquery{query: query, alias: alias})
+}
+
+// AppendUnion combines the query results with the ones of other, removing duplicates.
+func AppendUnion(q *Query, other *Query) {
+	q.touch()
+	q.combinations = append(q.combinations, combination{op: setOpUnion, query: other})
+}
+
+// AppendUnionAll combines the query results with the ones of other, keeping duplicates.
+func AppendUnionAll(q *Query, other *Query) {
+	q.touch()
+	q.combinations = append(q.combinations, combination{op: setOpUnionAll, query: other})
+}
+
+// AppendIntersect keeps the query results that are also returned by other.
+func AppendIntersect(q *Query, other *Query) {
+	q.touch()
+	q.combinations = append(q.combinations, combination{op: setOpIntersect, query: other})
+}
+
+// AppendExcept removes the query results that are also returned by other.
+func AppendExcept(q *Query, other *Query) {
+	q.touch()
+	q.combinations = append(q.combinations, combination{op: setOpExcept, query: other})
+}
+
 // SetFrom replaces the current from statements.
 func SetFrom(q *Query, from ...string) {
+	q.touch()
 	q.from = append([]string(nil), from...)
 }
 
 // AppendInnerJoin on the query.
 func AppendInnerJoin(q *Query, clause string, args ...interface{}) {
+	q.touch()
 	q.joins = append(q.joins, join{clause: clause, kind: JoinInner, args: args})
 }
 
+// AppendLeftOuterJoin on the query.
+func AppendLeftOuterJoin(q *Query, clause string, args ...interface{}) {
+	q.touch()
+	q.joins = append(q.joins, join{clause: clause, kind: JoinOuterLeft, args: args})
+}
+
+// AppendRightOuterJoin on the query.
+func AppendRightOuterJoin(q *Query, clause string, args ...interface{}) {
+	q.touch()
+	q.joins = append(q.joins, join{clause: clause, kind: JoinOuterRight, args: args})
+}
+
+// AppendFullOuterJoin on the query.
+func AppendFullOuterJoin(q *Query, clause string, args ...interface{}) {
+	q.touch()
+	q.joins = append(q.joins, join{clause: clause, kind: JoinOuterFull, args: args})
+}
+
+// AppendWith adds a common table expression to the query.
+func AppendWith(q *Query, name string, query *Query) {
+	q.touch()
+	q.with = append(q.with, with{name: name, query: query})
+}
+
+// AppendWithRecursive adds a recursive common table expression to the query.
+// Like in SQL, if any of the expressions is recursive, all of them are
+// declared with WITH RECURSIVE.
+func AppendWithRecursive(q *Query, name string, query *Query) {
+	q.touch()
+	q.withRecursive = true
+	q.with = append(q.with, with{name: name, query: query})
+}
+
 // AppendHaving on the query.
 func AppendHaving(q *Query, clause string, args ...interface{}) {
+	q.touch()
+	clause, args = namedArgs(clause, args)
 	q.having = append(q.having, having{clause: clause, args: args})
 }
 
 // AppendWhere on the query.
+//
+// Arguments can be named, as :name or @name, taking them from a single
+// map[string]any argument, or a struct with bunny tags.
 func AppendWhere(q *Query, clause string, args ...interface{}) {
+	q.touch()
+	clause, args = namedArgs(clause, args)
 	q.where = append(q.where, where{clause: clause, args: args})
 }
 
+// AppendWhereAnd appends the where and in clauses of sub to the query
+// as a single expression, joined with AND.
+func AppendWhereAnd(q *Query, sub *Query) {
+	q.touch()
+	q.where = append(q.where, where{kind: exprAnd, children: whereExprs(sub)})
+}
+
+// AppendWhereOr appends the where and in clauses of sub to the query
+// as a single expression, joined with OR.
+func AppendWhereOr(q *Query, sub *Query) {
+	q.touch()
+	q.where = append(q.where, where{kind: exprOr, children: whereExprs(sub)})
+}
+
+// AppendWhereNot appends the negation of the where and in clauses
+// of sub, joined with AND, to the query.
+func AppendWhereNot(q *Query, sub *Query) {
+	q.touch()
+	q.where = append(q.where, where{kind: exprNot, children: whereExprs(sub)})
+}
+
+// whereExprs returns the where and in clauses of q. The in clauses go
+// after the where clauses, as they do when rendering q.
+func whereExprs(q *Query) []where {
+	res := append([]where(nil), q.where...)
+	for _, in := range q.in {
+		res = append(res, where{kind: exprIn, clause: in.clause, args: in.args})
+	}
+	return res
+}
+
 // AppendIn on the query.
 func AppendIn(q *Query, clause string, args ...interface{}) {
+	q.touch()
 	q.in = append(q.in, in{clause: clause, args: args})
 }
 
 // AppendGroupBy on the query.
 func AppendGroupBy(q *Query, clause string) {
+	q.touch()
 	q.groupBy = append(q.groupBy, clause)
 }
 
 // AppendOrderBy on the query.
 func AppendOrderBy(q *Query, clause string) {
+	q.touch()
 	q.orderBy = append(q.orderBy, clause)
 }
@@ -19,9 +19,16 @@ func buildQuery(q *Query) (string, []interface{}) {
 	var buf *bytes.Buffer
 	var args []interface{}
 
+	if len(q.rawSQL.sql) != 0 {
+		return q.rawSQL.build(q.dialect), q.rawSQL.args
+	}
+
+	version := stateVersion(q)
+	if built, ok := q.built.Load().(*builtQuery); ok && built.version == version {
+		return built.sql, built.args
+	}
+
 	switch {
-	case len(q.rawSQL.sql) != 0:
-		return q.rawSQL.sql, q.rawSQL.args
 	case q.delete:
 		buf, args = buildDeleteQuery(q)
 	case len(q.update) > 0:
@@ -32,24 +39,35 @@ func buildQuery(q *Query) (string, []interface{}) {
 
 	defer strmangle.PutBuffer(buf)
 
-	// Cache the generated query for query object re-use
+	// Cache the generated query for query object re-use, until it's changed.
 	bufStr := buf.String()
-	q.rawSQL.sql = bufStr
-	q.rawSQL.args = args
+	q.built.Store(&builtQuery{version: version, sql: bufStr, args: args})
 
 	return bufStr, args
 }
 
 func buildSelectQuery(q *Query) (*bytes.Buffer, []interface{}) {
+	if q.count && len(q.combinations) != 0 {
+		return buildCombinedCountQuery(q)
+	}
+
 	buf := strmangle.GetBuffer()
 	var args []interface{}
 
+	writeCTEs(q, buf, &args)
+
+	top := q.dialect.UseTopClause && q.limit != 0 && q.offset == 0
+	combinedTop := top && len(q.combinations) != 0
+	if combinedTop {
+		// TOP in the first query would only limit its rows, so it's applied to the combined results.
+		fmt.Fprintf(buf, "SELECT TOP (%d) * FROM (", q.limit)
+	}
+	start := buf.Len()
+
 	buf.WriteString("SELECT ")
 
-	if q.dialect.UseTopClause {
-		if q.limit != 0 && q.offset == 0 {
-			fmt.Fprintf(buf, " TOP (%d) ", q.limit)
-		}
+	if top && !combinedTop {
+		fmt.Fprintf(buf, " TOP (%d) ", q.limit)
 	}
 
 	if q.count {
@@ -78,15 +96,34 @@ func buildSelectQuery(q *Query) (*bytes.Buffer, []interface{}) {
 
 	fmt.Fprintf(buf, " FROM %s", strings.Join(strmangle.IdentQuoteSlice(q.dialect.LQ, q.dialect.RQ, q.from), ", "))
 
+	if len(q.fromSubqueries) > 0 {
+		argsLen := len(args)
+		fromBuf := strmangle.GetBuffer()
+		for i, f := range q.fromSubqueries {
+			if i != 0 || len(q.from) != 0 {
+				fromBuf.WriteString(", ")
+			}
+			sql, subArgs := buildSubquery(q, f.query)
+			fmt.Fprintf(fromBuf, "(%s) AS %s", sql, strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, f.alias))
+			args = append(args, subArgs...)
+		}
+		var resp string
+		if q.dialect.IndexPlaceholders {
+			resp, _ = convertQuestionMarks(fromBuf.String(), argsLen+1)
+		} else {
+			resp = fromBuf.String()
+		}
+		buf.WriteString(resp)
+		strmangle.PutBuffer(fromBuf)
+	}
+
 	if len(q.joins) > 0 {
 		argsLen := len(args)
 		joinBuf := strmangle.GetBuffer()
 		for _, j := range q.joins {
-			if j.kind != JoinInner {
-				panic("only inner joins are supported")
-			}
-			fmt.Fprintf(joinBuf, " INNER JOIN %s", j.clause)
-			args = append(args, j.args...)
+			clause, joinArgs := expandSubqueries(q, j.clause, j.args)
+			fmt.Fprintf(joinBuf, " %s %s", j.kind, clause)
+			args = append(args, joinArgs...)
 		}
 		var resp string
 		if q.dialect.IndexPlaceholders {
@@ -110,20 +147,73 @@ func buildSelectQuery(q *Query) (*bytes.Buffer, []interface{}) {
 		args = append(args, inArgs...)
 	}
 
-	writeModifiers(q, buf, &args)
+	if len(q.combinations) != 0 {
+		// ORDER BY, LIMIT and OFFSET apply to the combined results.
+		writeGroupBy(q, buf, &args)
+		writeCombinations(q, buf, start, &args)
+		if combinedTop {
+			fmt.Fprintf(buf, ") AS %s", strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, "combined"))
+		}
+		writeOrderBy(q, buf)
+	} else {
+		writeModifiers(q, buf, &args)
+	}
+
+	buf.WriteByte(';')
+	return buf, args
+}
+
+// buildCombinedCountQuery counts the rows of a query with set operations,
+// which can't be counted by replacing the selected columns.
+func buildCombinedCountQuery(q *Query) (*bytes.Buffer, []interface{}) {
+	buf := strmangle.GetBuffer()
+
+	inner := q.Clone()
+	inner.count = false
+	sql, args := buildSubquery(q, inner)
+
+	fmt.Fprintf(buf, "SELECT COUNT(*) FROM (%s) AS %s", sql, strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, "combined"))
+	if q.dialect.IndexPlaceholders {
+		resp, _ := convertQuestionMarks(buf.String(), 1)
+		buf.Reset()
+		buf.WriteString(resp)
+	}
 
 	buf.WriteByte(';')
 	return buf, args
 }
 
+// writeCombinations writes the set operations of the query, with the combined queries in parentheses.
+// They're applied left to right, so the query from start is parenthesized before each
+// operation but the first, instead of following the SQL precedence of the operators.
+func writeCombinations(q *Query, buf *bytes.Buffer, start int, args *[]interface{}) {
+	for i, c := range q.combinations {
+		if i != 0 {
+			left := buf.String()
+			buf.Reset()
+			fmt.Fprintf(buf, "%s(%s)", left[:start], left[start:])
+		}
+
+		argsLen := len(*args)
+		sql, subArgs := buildSubquery(q, c.query)
+		*args = append(*args, subArgs...)
+		if q.dialect.IndexPlaceholders {
+			sql, _ = convertQuestionMarks(sql, argsLen+1)
+		}
+		fmt.Fprintf(buf, " %s (%s)", c.op, sql)
+	}
+}
+
 func buildDeleteQuery(q *Query) (*bytes.Buffer, []interface{}) {
 	var args []interface{}
 	buf := strmangle.GetBuffer()
 
+	writeCTEs(q, buf, &args)
+
 	buf.WriteString("DELETE FROM ")
 	buf.WriteString(strings.Join(strmangle.IdentQuoteSlice(q.dialect.LQ, q.dialect.RQ, q.from), ", "))
 
-	where, whereArgs := whereClause(q, 1)
+	where, whereArgs := whereClause(q, len(args)+1)
 	if len(whereArgs) != 0 {
 		args = append(args, whereArgs...)
 	}
@@ -144,12 +234,15 @@ func buildDeleteQuery(q *Query) (*bytes.Buffer, []interface{}) {
 
 func buildUpdateQuery(q *Query) (*bytes.Buffer, []interface{}) {
 	buf := strmangle.GetBuffer()
+	var args []interface{}
+
+	writeCTEs(q, buf, &args)
+	argsLen := len(args)
 
 	buf.WriteString("UPDATE ")
 	buf.WriteString(strings.Join(strmangle.IdentQuoteSlice(q.dialect.LQ, q.dialect.RQ, q.from), ", "))
 
 	cols := make(sort.StringSlice, len(q.update))
-	var args []interface{}
 
 	count := 0
 	for name := range q.update {
@@ -166,7 +259,7 @@ func buildUpdateQuery(q *Query) (*bytes.Buffer, []interface{}) {
 
 	setSlice := make([]string, len(cols))
 	for index, col := range cols {
-		setSlice[index] = fmt.Sprintf("%s = %s", col, strmangle.Placeholders(q.dialect.IndexPlaceholders, 1, index+1, 1))
+		setSlice[index] = fmt.Sprintf("%s = %s", col, strmangle.Placeholders(q.dialect.IndexPlaceholders, 1, argsLen+index+1, 1))
 	}
 	fmt.Fprintf(buf, " SET %s", strings.Join(setSlice, ", "))
 
@@ -236,6 +329,14 @@ func BuildUpsertQueryMySQL(dia Dialect, modelName string, update, whitelist []st
 
 // BuildUpsertQueryPostgres builds a SQL statement string using the upsertData provided.
 func BuildUpsertQueryPostgres(dia Dialect, modelName string, updateOnConflict bool, ret, update, conflict, whitelist []string) string {
+	return BuildUpsertQueryPostgresWhere(dia, modelName, updateOnConflict, ret, update, nil, conflict, whitelist, "")
+}
+
+// BuildUpsertQueryPostgresWhere is like BuildUpsertQueryPostgres, but also sets the
+// assignments, expressions like `count = "t"."count" + EXCLUDED."count"` used as is,
+// and only updates the conflicting row if it matches the where condition.
+// where can't have placeholders.
+func BuildUpsertQueryPostgresWhere(dia Dialect, modelName string, updateOnConflict bool, ret, update, assignments, conflict, whitelist []string, where string) string {
 	conflict = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, conflict)
 	whitelist = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, whitelist)
 	ret = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, ret)
@@ -257,7 +358,7 @@ func BuildUpsertQueryPostgres(dia Dialect, modelName string, updateOnConflict bo
 		fields,
 	)
 
-	if !updateOnConflict || len(update) == 0 {
+	if !updateOnConflict || len(update)+len(assignments) == 0 {
 		buf.WriteString("DO NOTHING")
 	} else {
 		buf.WriteByte('(')
@@ -273,6 +374,17 @@ func BuildUpsertQueryPostgres(dia Dialect, modelName string, updateOnConflict bo
 			buf.WriteString(" = EXCLUDED.")
 			buf.WriteString(quoted)
 		}
+		for i, v := range assignments {
+			if i != 0 || len(update) != 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(v)
+		}
+
+		if where != "" {
+			buf.WriteString(" WHERE ")
+			buf.WriteString(where)
+		}
 	}
 
 	if len(ret) != 0 {
@@ -283,6 +395,62 @@ func BuildUpsertQueryPostgres(dia Dialect, modelName string, updateOnConflict bo
 	return buf.String()
 }
 
+// BuildUpdateAllQueryPostgres builds a statement updating rows rows with different values,
+// joining the table with a VALUES list of the update and primary key columns. types are
+// the column SQL types, which the values are cast to.
+func BuildUpdateAllQueryPostgres(dia Dialect, modelName string, update, primary []string, types map[string]string, rows int) string {
+	cols := append(append([]string{}, update...), primary...)
+	v := strmangle.IdentQuote(dia.LQ, dia.RQ, "v")
+
+	buf := strmangle.GetBuffer()
+	defer strmangle.PutBuffer(buf)
+
+	fmt.Fprintf(buf, "UPDATE %s SET ", modelName)
+	for i, c := range update {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+		quoted := strmangle.IdentQuote(dia.LQ, dia.RQ, c)
+		fmt.Fprintf(buf, "%s = %s.%s", quoted, v, quoted)
+	}
+
+	buf.WriteString(" FROM (VALUES ")
+	n := 1
+	for r := 0; r < rows; r++ {
+		if r != 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('(')
+		for i, c := range cols {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+			if dia.IndexPlaceholders {
+				fmt.Fprintf(buf, "$%d", n)
+			} else {
+				buf.WriteByte('?')
+			}
+			n++
+			if t := types[c]; t != "" {
+				buf.WriteString("::")
+				buf.WriteString(t)
+			}
+		}
+		buf.WriteByte(')')
+	}
+	fmt.Fprintf(buf, ") AS %s (%s) WHERE ", v, strings.Join(strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, cols), ","))
+
+	for i, c := range primary {
+		if i != 0 {
+			buf.WriteString(" AND ")
+		}
+		quoted := strmangle.IdentQuote(dia.LQ, dia.RQ, c)
+		fmt.Fprintf(buf, "%s.%s = %s.%s", modelName, quoted, v, quoted)
+	}
+
+	return buf.String()
+}
+
 // BuildUpsertQueryMSSQL builds a SQL statement string using the upsertData provided.
 func BuildUpsertQueryMSSQL(dia Dialect, modelName string, primary, update, insert []string, output []string) string {
 	insert = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, insert)
@@ -326,7 +494,63 @@ func BuildUpsertQueryMSSQL(dia Dialect, modelName string, primary, update, inser
 	return buf.String()
 }
 
+// writeCTEs writes the WITH clause of the query, with the common table
+// expressions and their arguments.
+func writeCTEs(q *Query, buf *bytes.Buffer, args *[]interface{}) {
+	if len(q.with) == 0 {
+		return
+	}
+
+	argsLen := len(*args)
+	withBuf := strmangle.GetBuffer()
+	defer strmangle.PutBuffer(withBuf)
+
+	withBuf.WriteString("WITH ")
+	if q.withRecursive {
+		withBuf.WriteString("RECURSIVE ")
+	}
+	for i, w := range q.with {
+		if i != 0 {
+			withBuf.WriteString(", ")
+		}
+		sql, subArgs := buildSubquery(q, w.query)
+		fmt.Fprintf(withBuf, "%s AS (%s)", w.name, sql)
+		*args = append(*args, subArgs...)
+	}
+	withBuf.WriteByte(' ')
+
+	if q.dialect.IndexPlaceholders {
+		resp, _ := convertQuestionMarks(withBuf.String(), argsLen+1)
+		buf.WriteString(resp)
+	} else {
+		buf.Write(withBuf.Bytes())
+	}
+}
+
+// buildSubquery builds sub to be embedded in q. The returned SQL has ? placeholders,
+// for q to number them, and no trailing semicolon. Raw subqueries must use ? placeholders too.
+func buildSubquery(q *Query, sub *Query) (string, []interface{}) {
+	// Build a copy, so the SQL with ? placeholders isn't cached in sub.
+	cp := sub.Clone()
+
+	dialect := *q.dialect
+	if cp.dialect != nil {
+		dialect = *cp.dialect
+	}
+	dialect.IndexPlaceholders = false
+	cp.dialect = &dialect
+
+	sql, args := buildQuery(cp)
+	return strings.TrimSuffix(strings.TrimSpace(sql), ";"), args
+}
+
 func writeModifiers(q *Query, buf *bytes.Buffer, args *[]interface{}) {
+	writeGroupBy(q, buf, args)
+	writeOrderBy(q, buf)
+}
+
+// writeGroupBy writes the GROUP BY and HAVING clauses.
+func writeGroupBy(q *Query, buf *bytes.Buffer, args *[]interface{}) {
 	if len(q.groupBy) != 0 {
 		fmt.Fprintf(buf, " GROUP BY %s", strings.Join(q.groupBy, ", "))
 	}
@@ -339,8 +563,9 @@ func writeModifiers(q *Query, buf *bytes.Buffer, args *[]interface{}) {
 			if i > 0 {
 				fmt.Fprintf(havingBuf, " AND ")
 			}
-			fmt.Fprintf(havingBuf, j.clause)
-			*args = append(*args, j.args...)
+			clause, havingArgs := expandSubqueries(q, j.clause, j.args)
+			fmt.Fprintf(havingBuf, clause)
+			*args = append(*args, havingArgs...)
 		}
 		var resp string
 		if q.dialect.IndexPlaceholders {
@@ -351,7 +576,10 @@ func writeModifiers(q *Query, buf *bytes.Buffer, args *[]interface{}) {
 		buf.WriteString(resp)
 		strmangle.PutBuffer(havingBuf)
 	}
+}
 
+// writeOrderBy writes the ORDER BY, LIMIT, OFFSET and FOR clauses.
+func writeOrderBy(q *Query, buf *bytes.Buffer) {
 	if len(q.orderBy) != 0 {
 		buf.WriteString(" ORDER BY ")
 		buf.WriteString(strings.Join(q.orderBy, ", "))
@@ -414,6 +642,10 @@ func writeStars(q *Query) []string {
 		cols[i] = fmt.Sprintf(`%s.*`, strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, name))
 	}
 
+	for _, f := range q.fromSubqueries {
+		cols = append(cols, fmt.Sprintf(`%s.*`, strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, f.alias)))
+	}
+
 	return cols
 }
 
@@ -462,18 +694,73 @@ func whereClause(q *Query, startAt int) (string, []interface{}) {
 			buf.WriteString(" AND ")
 		}
 
-		buf.WriteString(fmt.Sprintf("(%s)", where.clause))
-		args = append(args, where.args...)
+		buf.WriteByte('(')
+		startAt = writeWhere(q, buf, where, startAt, &args)
+		buf.WriteByte(')')
+	}
+
+	return buf.String(), args
+}
+
+// writeWhere writes a where clause, or an expression built from nested
+// clauses, converting its placeholders starting at startAt. It returns
+// the number the next placeholder starts at.
+func writeWhere(q *Query, buf *bytes.Buffer, w where, startAt int, args *[]interface{}) int {
+	switch w.kind {
+	case exprIn:
+		clause, inArgs, count := convertIn(q, w.clause, w.args, startAt)
+		buf.WriteString(clause)
+		*args = append(*args, inArgs...)
+		return startAt + count
+	case exprAnd, exprOr:
+		sep := " AND "
+		if w.kind == exprOr {
+			sep = " OR "
+		}
+		return writeWhereList(q, buf, w.children, sep, startAt, args)
+	case exprNot:
+		buf.WriteString("NOT ")
+		if len(w.children) > 1 {
+			buf.WriteByte('(')
+		}
+		startAt = writeWhereList(q, buf, w.children, " AND ", startAt, args)
+		if len(w.children) > 1 {
+			buf.WriteByte(')')
+		}
+		return startAt
 	}
 
-	var resp string
+	clause, whereArgs := expandSubqueries(q, w.clause, w.args)
 	if q.dialect.IndexPlaceholders {
-		resp, _ = convertQuestionMarks(buf.String(), startAt)
-	} else {
-		resp = buf.String()
+		var count int
+		clause, count = convertQuestionMarks(clause, startAt)
+		startAt += count
 	}
+	buf.WriteString(clause)
+	*args = append(*args, whereArgs...)
+	return startAt
+}
 
-	return resp, args
+func writeWhereList(q *Query, buf *bytes.Buffer, list []where, sep string, startAt int, args *[]interface{}) int {
+	if len(list) == 0 {
+		// An empty AND is true, an empty OR is false.
+		if sep == " OR " {
+			buf.WriteString("FALSE")
+		} else {
+			buf.WriteString("TRUE")
+		}
+		return startAt
+	}
+
+	for i, w := range list {
+		if i != 0 {
+			buf.WriteString(sep)
+		}
+		buf.WriteByte('(')
+		startAt = writeWhere(q, buf, w, startAt, args)
+		buf.WriteByte(')')
+	}
+	return startAt
 }
 
 // inClause parses an in slice and converts it into a
@@ -493,7 +780,6 @@ func inClause(q *Query, startAt int) (string, []interface{}) {
 	}
 
 	for i, in := range q.in {
-		ln := len(in.args)
 		// We only prefix the OR and AND separators after the first
 		// clause has been generated UNLESS there is already a where
 		// clause that we have to add on to.
@@ -501,50 +787,129 @@ func inClause(q *Query, startAt int) (string, []interface{}) {
 			buf.WriteString(" AND ")
 		}
 
-		matches := rgxInClause.FindStringSubmatch(in.clause)
-		// If we can't find any matches attempt a simple replace with 1 group.
-		// Clauses that fit this criteria will not be able to contain ? in their
-		// field name side, however if this case is being hit then the regexp
-		// probably needs adjustment, or the user is passing in invalid clauses.
-		if matches == nil {
-			clause, count := convertInQuestionMarks(q.dialect.IndexPlaceholders, in.clause, startAt, 1, ln)
-			buf.WriteString(clause)
-			startAt = startAt + count
-		} else {
-			leftSide := strings.TrimSpace(matches[1])
-			rightSide := strings.TrimSpace(matches[2])
-			// If matches are found, we have to parse the left side (field side)
-			// of the clause to determine how many fields they are using.
-			// This number determines the groupAt for the convert function.
-			cols := strings.Split(leftSide, ",")
-			cols = strmangle.IdentQuoteSlice(q.dialect.LQ, q.dialect.RQ, cols)
-			groupAt := len(cols)
-
-			var leftClause string
-			var leftCount int
-			if q.dialect.IndexPlaceholders {
-				leftClause, leftCount = convertQuestionMarks(strings.Join(cols, ","), startAt)
-			} else {
-				// Count the number of cols that are question marks, so we know
-				// how much to offset convertInQuestionMarks by
-				for _, v := range cols {
-					if v == "?" {
-						leftCount++
-					}
-				}
-				leftClause = strings.Join(cols, ",")
+		clause, inArgs, count := convertIn(q, in.clause, in.args, startAt)
+		buf.WriteString(clause)
+		startAt = startAt + count
+
+		args = append(args, inArgs...)
+	}
+
+	return buf.String(), args
+}
+
+// convertIn converts a single IN clause, like "("a", "b") IN ?", into
+// "("a", "b") IN (($1,$2),($3,$4))". If the argument is a subquery, it's inlined
+// instead, like "a IN (SELECT ...)". It returns the clause, its arguments and
+// the number of placeholders written.
+func convertIn(q *Query, clause string, args []interface{}, startAt int) (string, []interface{}, int) {
+	if hasSubqueries(args) {
+		clause, args = expandSubqueries(q, clause, args)
+		if !q.dialect.IndexPlaceholders {
+			return clause, args, len(args)
+		}
+		clause, count := convertQuestionMarks(clause, startAt)
+		return clause, args, count
+	}
+
+	clause, count := convertInList(q, clause, startAt, len(args))
+	return clause, args, count
+}
+
+func convertInList(q *Query, clause string, startAt int, ln int) (string, int) {
+	matches := rgxInClause.FindStringSubmatch(clause)
+	// If we can't find any matches attempt a simple replace with 1 group.
+	// Clauses that fit this criteria will not be able to contain ? in their
+	// field name side, however if this case is being hit then the regexp
+	// probably needs adjustment, or the user is passing in invalid clauses.
+	if matches == nil {
+		return convertInQuestionMarks(q.dialect.IndexPlaceholders, clause, startAt, 1, ln)
+	}
+
+	leftSide := strings.TrimSpace(matches[1])
+	rightSide := strings.TrimSpace(matches[2])
+	// If matches are found, we have to parse the left side (field side)
+	// of the clause to determine how many fields they are using.
+	// This number determines the groupAt for the convert function.
+	cols := strings.Split(leftSide, ",")
+	cols = strmangle.IdentQuoteSlice(q.dialect.LQ, q.dialect.RQ, cols)
+	groupAt := len(cols)
+
+	var leftClause string
+	var leftCount int
+	if q.dialect.IndexPlaceholders {
+		leftClause, leftCount = convertQuestionMarks(strings.Join(cols, ","), startAt)
+	} else {
+		// Count the number of cols that are question marks, so we know
+		// how much to offset convertInQuestionMarks by
+		for _, v := range cols {
+			if v == "?" {
+				leftCount++
 			}
-			rightClause, rightCount := convertInQuestionMarks(q.dialect.IndexPlaceholders, rightSide, startAt+leftCount, groupAt, ln-leftCount)
-			buf.WriteString(leftClause)
-			buf.WriteString(" IN ")
-			buf.WriteString(rightClause)
-			startAt = startAt + leftCount + rightCount
 		}
+		leftClause = strings.Join(cols, ",")
+	}
+	rightClause, rightCount := convertInQuestionMarks(q.dialect.IndexPlaceholders, rightSide, startAt+leftCount, groupAt, ln-leftCount)
+	return leftClause + " IN " + rightClause, leftCount + rightCount
+}
 
-		args = append(args, in.args...)
+// subquery is implemented by *Query, and by the types that embed it,
+// such as the generated model queries.
+type subquery interface {
+	subquery() *Query
+}
+
+func (q *Query) subquery() *Query {
+	return q
+}
+
+func hasSubqueries(args []interface{}) bool {
+	for _, a := range args {
+		if _, ok := a.(subquery); ok {
+			return true
+		}
 	}
+	return false
+}
 
-	return buf.String(), args
+// expandSubqueries inlines the SQL of the subquery arguments of clause, in parentheses,
+// in place of their ? placeholders, and replaces them by their own arguments.
+func expandSubqueries(q *Query, clause string, args []interface{}) (string, []interface{}) {
+	if !hasSubqueries(args) {
+		return clause, args
+	}
+
+	buf := strmangle.GetBuffer()
+	defer strmangle.PutBuffer(buf)
+	var res []interface{}
+
+	n := 0
+	for i := 0; i < len(clause); i++ {
+		c := clause[i]
+		if c == '\\' && i+1 < len(clause) && clause[i+1] == '?' {
+			buf.WriteString(`\?`)
+			i++
+			continue
+		}
+		if c != '?' || n >= len(args) {
+			buf.WriteByte(c)
+			continue
+		}
+
+		if sub, ok := args[n].(subquery); ok {
+			sql, subArgs := buildSubquery(q, sub.subquery())
+			buf.WriteByte('(')
+			buf.WriteString(sql)
+			buf.WriteByte(')')
+			res = append(res, subArgs...)
+		} else {
+			buf.WriteByte('?')
+			res = append(res, args[n])
+		}
+		n++
+	}
+	res = append(res, args[n:]...)
+
+	return buf.String(), res
 }
 
 // convertInQuestionMarks finds the first unescaped occurrence of ? and swaps it
@@ -93,6 +93,149 @@ func TestBuildQuery(t *testing.T) {
 		{&Query{from: []string{"cats c"}, joins: []join{{JoinInner, "dogs d on d.cat_id = cats.id", nil}}}, nil},
 		{&Query{from: []string{"cats as c"}, joins: []join{{JoinInner, "dogs d on d.cat_id = cats.id", nil}}}, nil},
 		{&Query{from: []string{"cats as c", "dogs as d"}, joins: []join{{JoinInner, "dogs d on d.cat_id = cats.id", nil}}}, nil},
+		{&Query{from: []string{"cats c"}, joins: []join{{JoinOuterLeft, "dogs d on d.cat_id = c.id", nil}}}, nil},
+		{&Query{from: []string{"cats c"}, joins: []join{{JoinOuterRight, "dogs d on d.cat_id = c.id", nil}}}, nil},
+		{&Query{from: []string{"cats c"}, joins: []join{{JoinOuterFull, "dogs d on d.cat_id = c.id", nil}}}, nil},
+		{&Query{
+			from: []string{"cats c"},
+			joins: []join{
+				{JoinInner, "owners o on o.id = c.owner_id and o.active = ?", []interface{}{true}},
+				{JoinOuterLeft, "dogs d on d.cat_id = c.id and d.age > ?", []interface{}{3}},
+				{JoinOuterFull, "toys t on t.cat_id = c.id and t.color in (?, ?)", []interface{}{"red", "blue"}},
+			},
+			where: []where{{clause: "c.name = ?", args: []interface{}{"tom"}}},
+		}, []interface{}{true, 3, "red", "blue", "tom"}},
+		{&Query{
+			from: []string{"t"},
+			where: []where{
+				{kind: exprOr, children: []where{
+					{clause: "a = ?", args: []interface{}{1}},
+					{clause: "b = ? and c = ?", args: []interface{}{2, 3}},
+				}},
+				{clause: "d = ?", args: []interface{}{4}},
+			},
+		}, []interface{}{1, 2, 3, 4}},
+		{&Query{
+			from: []string{"t"},
+			where: []where{
+				{kind: exprAnd, children: []where{
+					{clause: "a = ?", args: []interface{}{1}},
+					{kind: exprOr, children: []where{
+						{kind: exprIn, clause: "b in ?", args: []interface{}{2, 3, 4}},
+						{kind: exprNot, children: []where{
+							{clause: "c = ?", args: []interface{}{5}},
+						}},
+						{kind: exprNot, children: []where{
+							{clause: "d = ?", args: []interface{}{6}},
+							{kind: exprIn, clause: "(e, f) in ?", args: []interface{}{7, 8, 9, 10}},
+						}},
+					}},
+				}},
+			},
+			in: []in{{clause: "g in ?", args: []interface{}{11, 12}}},
+		}, []interface{}{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+		{&Query{
+			from:  []string{"t"},
+			where: []where{{kind: exprOr}},
+		}, nil},
+		{&Query{
+			with: []with{
+				{name: "young", query: &Query{from: []string{"people"}, where: []where{{clause: "age < ?", args: []interface{}{18}}}}},
+				{name: "named(id)", query: Raw("select id from names where name = ?", "bob")},
+			},
+			from:  []string{"young"},
+			joins: []join{{JoinInner, "named n on n.id = young.id and n.id > ?", []interface{}{5}}},
+			where: []where{{clause: "young.age > ?", args: []interface{}{10}}},
+		}, []interface{}{18, "bob", 5, 10}},
+		{&Query{
+			withRecursive: true,
+			with: []with{
+				{name: "tree(id, parent_id)", query: Raw("select id, parent_id from nodes where id = ? union all select n.id, n.parent_id from nodes n join tree t on n.parent_id = t.id", 1)},
+			},
+			from: []string{"tree"},
+		}, []interface{}{1}},
+		{&Query{
+			with: []with{
+				{name: "old", query: &Query{selectCols: []string{"id"}, from: []string{"people"}, in: []in{{clause: "age in ?", args: []interface{}{80, 90}}}}},
+			},
+			from:   []string{"people"},
+			update: map[string]interface{}{"retired": true},
+			where:  []where{{clause: "id in (select id from old) and name <> ?", args: []interface{}{"x"}}},
+		}, []interface{}{80, 90, true, "x"}},
+		{&Query{
+			with: []with{
+				{name: "gone", query: &Query{selectCols: []string{"id"}, from: []string{"people"}, where: []where{{clause: "deleted = ?", args: []interface{}{true}}}}},
+			},
+			delete: true,
+			from:   []string{"people"},
+			where:  []where{{clause: "id in (select id from gone) and age > ?", args: []interface{}{3}}},
+		}, []interface{}{true, 3}},
+		{&Query{
+			from: []string{"books"},
+			where: []where{
+				{clause: "price > ?", args: []interface{}{10}},
+				{clause: "EXISTS ? and stock > ?", args: []interface{}{
+					&Query{from: []string{"reviews"}, where: []where{{clause: "reviews.book_id = books.id and stars >= ?", args: []interface{}{4}}}},
+					2,
+				}},
+			},
+			in: []in{{clause: "author_id in ?", args: []interface{}{
+				&Query{selectCols: []string{"id"}, from: []string{"authors"}, where: []where{{clause: "country = ?", args: []interface{}{"ES"}}}},
+			}}},
+		}, []interface{}{10, 4, 2, "ES"}},
+		{&Query{
+			selectCols: []string{"t.id"},
+			fromSubqueries: []fromSubquery{
+				{alias: "t", query: &Query{from: []string{"books"}, where: []where{{clause: "price > ?", args: []interface{}{5}}}}},
+			},
+			joins: []join{{JoinInner, "authors a on a.id = t.author_id and a.age > ?", []interface{}{30}}},
+			where: []where{{kind: exprOr, children: []where{
+				{kind: exprIn, clause: "t.id in ?", args: []interface{}{Raw("select book_id from sales where year = ?", 2020)}},
+				{clause: "t.id = ?", args: []interface{}{1}},
+			}}},
+		}, []interface{}{5, 30, 2020, 1}},
+		{&Query{
+			from:           []string{"authors"},
+			fromSubqueries: []fromSubquery{{alias: "b", query: Raw("select * from books where id = ?", 1)}},
+			joins:          []join{{JoinInner, "x on true", nil}},
+		}, []interface{}{1}},
+		{&Query{
+			from:  []string{"books"},
+			where: []where{{clause: "price > ?", args: []interface{}{10}}},
+			combinations: []combination{
+				{op: setOpUnion, query: &Query{from: []string{"books"}, where: []where{{clause: "stock < ?", args: []interface{}{3}}}}},
+				{op: setOpExcept, query: Raw("select * from books where id = ?", 7)},
+			},
+			orderBy: []string{"id"},
+			limit:   10,
+			offset:  20,
+		}, []interface{}{10, 3, 7}},
+		{&Query{
+			selectCols: []string{"author_id"},
+			from:       []string{"books"},
+			groupBy:    []string{"author_id"},
+			having:     []having{{clause: "count(*) > ?", args: []interface{}{2}}},
+			combinations: []combination{
+				{op: setOpUnionAll, query: &Query{selectCols: []string{"id"}, from: []string{"authors"}, in: []in{{clause: "country in ?", args: []interface{}{"ES", "FR"}}}}},
+				{op: setOpIntersect, query: &Query{selectCols: []string{"id"}, from: []string{"authors"}, limit: 5}},
+			},
+		}, []interface{}{2, "ES", "FR"}},
+		{&Query{
+			count: true,
+			from:  []string{"books"},
+			where: []where{{clause: "price > ?", args: []interface{}{10}}},
+			combinations: []combination{
+				{op: setOpUnion, query: &Query{from: []string{"books"}, where: []where{{clause: "stock < ?", args: []interface{}{3}}}}},
+			},
+		}, []interface{}{10, 3}},
+		{&Query{
+			count:      true,
+			selectCols: []string{"id"},
+			from:       []string{"a"},
+			combinations: []combination{
+				{op: setOpUnion, query: &Query{selectCols: []string{"id"}, from: []string{"b"}}},
+			},
+		}, nil},
 	}
 
 	for i, test := range tests {
@@ -486,3 +629,107 @@ func TestWriteAsStatements(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildSubqueryNotCached(t *testing.T) {
+	t.Parallel()
+
+	dialect := &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}
+	sub := &Query{dialect: dialect, from: []string{"a"}, where: []where{{clause: "x = ?", args: []interface{}{1}}}}
+	q := &Query{dialect: dialect, from: []string{"t"}, with: []with{{name: "s", query: sub}}}
+
+	buildQuery(q)
+	if sub.rawSQL.sql != "" {
+		t.Errorf("subquery SQL was cached: %s", sub.rawSQL.sql)
+	}
+
+	if out, _ := buildQuery(sub); out != `SELECT * FROM "a" WHERE (x = $1);` {
+		t.Errorf("wrong subquery SQL: %s", out)
+	}
+
+	q = &Query{dialect: dialect, from: []string{"t"}, with: []with{{name: "s", query: sub}}}
+	if out, _ := buildQuery(q); out != `WITH s AS (SELECT * FROM "a" WHERE (x = $1)) SELECT * FROM "t";` {
+		t.Errorf("wrong query SQL: %s", out)
+	}
+}
+
+func TestBuildCombinedTop(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{
+		dialect: &Dialect{LQ: '[', RQ: ']', UseTopClause: true},
+		from:    []string{"a"},
+		combinations: []combination{
+			{op: setOpUnion, query: &Query{from: []string{"b"}}},
+		},
+		orderBy: []string{"id"},
+		limit:   5,
+	}
+
+	want := `SELECT TOP (5) * FROM (SELECT * FROM [a] UNION (SELECT * FROM [b])) AS [combined] ORDER BY id;`
+	if out, _ := buildQuery(q); out != want {
+		t.Errorf("Want:\n%s\nGot:\n%s", want, out)
+	}
+}
+
+func TestBuildUpsertQueryPostgresWhere(t *testing.T) {
+	t.Parallel()
+
+	dia := Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}
+	tests := []struct {
+		update      []string
+		assignments []string
+		where       string
+		want        string
+	}{
+		{
+			update: []string{"name"},
+			want:   `INSERT INTO "t" ("id", "name") VALUES ($1,$2) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name" RETURNING "id", "name"`,
+		},
+		{
+			update:      []string{"name"},
+			assignments: []string{`count = "t"."count" + EXCLUDED."count"`},
+			where:       `"t"."name" <> EXCLUDED."name"`,
+			want:   `INSERT INTO "t" ("id", "name") VALUES ($1,$2) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name",count = "t"."count" + EXCLUDED."count" WHERE "t"."name" <> EXCLUDED."name" RETURNING "id", "name"`,
+		},
+		{
+			assignments: []string{`count = "t"."count" + 1`},
+			want:        `INSERT INTO "t" ("id", "name") VALUES ($1,$2) ON CONFLICT ("id") DO UPDATE SET count = "t"."count" + 1 RETURNING "id", "name"`,
+		},
+		{
+			where: `"t"."name" <> EXCLUDED."name"`,
+			want:  `INSERT INTO "t" ("id", "name") VALUES ($1,$2) ON CONFLICT DO NOTHING RETURNING "id", "name"`,
+		},
+	}
+
+	for i, test := range tests {
+		got := BuildUpsertQueryPostgresWhere(dia, `"t"`, true, []string{"id", "name"}, test.update, test.assignments, []string{"id"}, []string{"id", "name"}, test.where)
+		if got != test.want {
+			t.Errorf("%d) Want:\n%s\nGot:\n%s", i, test.want, got)
+		}
+	}
+}
+
+func TestBuildUpsertQueryPostgres(t *testing.T) {
+	t.Parallel()
+
+	// update entries are always column names, even if they look like assignments.
+	dia := Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}
+	got := BuildUpsertQueryPostgres(dia, `"t"`, true, nil, []string{"a=b"}, []string{"id"}, []string{"id"})
+	want := `INSERT INTO "t" ("id") VALUES ($1) ON CONFLICT ("id") DO UPDATE SET a=b = EXCLUDED.a=b`
+	if got != want {
+		t.Errorf("Want:\n%s\nGot:\n%s", want, got)
+	}
+}
+
+func TestBuildUpdateAllQueryPostgres(t *testing.T) {
+	t.Parallel()
+
+	dia := Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}
+	types := map[string]string{"id": "text", "name": "text", "count": "bigint"}
+
+	got := BuildUpdateAllQueryPostgres(dia, `"t"`, []string{"name", "count"}, []string{"id"}, types, 2)
+	want := `UPDATE "t" SET "name" = "v"."name","count" = "v"."count" FROM (VALUES ($1::text,$2::bigint,$3::text),($4::text,$5::bigint,$6::text)) AS "v" ("name","count","id") WHERE "t"."id" = "v"."id"`
+	if got != want {
+		t.Errorf("Want:\n%s\nGot:\n%s", want, got)
+	}
+}
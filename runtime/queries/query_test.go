@@ -233,6 +233,20 @@ func TestSetSelect(t *testing.T) {
 	}
 }
 
+func TestSelect(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{selectCols: []string{"hello"}, from: []string{"t"}}
+	sub := q.Select("id")
+
+	if !reflect.DeepEqual(sub.selectCols, []string{"id"}) {
+		t.Errorf("Got invalid select: %v", sub.selectCols)
+	}
+	if !reflect.DeepEqual(q.selectCols, []string{"hello"}) {
+		t.Errorf("Select changed the original query: %v", q.selectCols)
+	}
+}
+
 func TestSetCount(t *testing.T) {
 	t.Parallel()
 
@@ -344,3 +358,64 @@ func TestAppendInnerJoin(t *testing.T) {
 		t.Errorf("Got invalid innerJoin on string: %#v", q.joins)
 	}
 }
+
+func TestBuildQueryAfterChange(t *testing.T) {
+	t.Parallel()
+
+	dialect := &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}
+	sub := &Query{dialect: dialect, selectCols: []string{"id"}, from: []string{"b"}}
+	q := &Query{dialect: dialect, from: []string{"a"}}
+	AppendWhere(q, "x = ?", 1)
+	AppendIn(q, "id in ?", sub)
+
+	sql1, _ := buildQuery(q)
+	sql2, _ := buildQuery(q)
+	if sql1 != sql2 {
+		t.Errorf("Expected the same SQL, got %q and %q", sql1, sql2)
+	}
+
+	SetLimit(q, 5)
+	want := `SELECT * FROM "a" WHERE (x = $1) AND id in (SELECT "id" FROM "b") LIMIT 5;`
+	if sql, _ := buildQuery(q); sql != want {
+		t.Errorf("Want:\n%s\nGot:\n%s", want, sql)
+	}
+
+	AppendWhere(sub, "y = ?", 2)
+	want = `SELECT * FROM "a" WHERE (x = $1) AND id in (SELECT "id" FROM "b" WHERE (y = $2)) LIMIT 5;`
+	sql, args := buildQuery(q)
+	if sql != want {
+		t.Errorf("Want:\n%s\nGot:\n%s", want, sql)
+	}
+	if !reflect.DeepEqual(args, []interface{}{1, 2}) {
+		t.Errorf("Got invalid args: %v", args)
+	}
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	dialect := &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}
+	base := &Query{dialect: dialect, from: []string{"a"}, where: make([]where, 0, 4)}
+	AppendWhere(base, "x = ?", 1)
+	buildQuery(base)
+
+	c1 := base.Clone()
+	AppendWhere(c1, "y = ?", 2)
+	SetCount(c1)
+	c2 := base.Clone()
+	AppendWhere(c2, "z = ?", 3)
+
+	tests := []struct {
+		q    *Query
+		want string
+	}{
+		{base, `SELECT * FROM "a" WHERE (x = $1);`},
+		{c1, `SELECT COUNT(*) FROM "a" WHERE (x = $1) AND (y = $2);`},
+		{c2, `SELECT * FROM "a" WHERE (x = $1) AND (z = $2);`},
+	}
+	for i, test := range tests {
+		if sql, _ := buildQuery(test.q); sql != test.want {
+			t.Errorf("%d) Want:\n%s\nGot:\n%s", i, test.want, sql)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+// Package querytest has helpers to check queries in tests.
+package querytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sqlbunny/sqlbunny/runtime/queries"
+)
+
+// AssertNoSeqScan fails the test if the plan of the query scans any of the tables
+// sequentially, or any table at all if none are given.
+//
+// Postgres prefers sequential scans on small tables, so tests with little data
+// should run it after SET enable_seqscan = off on the same connection.
+func AssertNoSeqScan(t testing.TB, ctx context.Context, q *queries.Query, tables ...string) {
+	t.Helper()
+
+	e, err := q.Explain(ctx, queries.ExplainOptions{})
+	if err != nil {
+		t.Fatalf("failed to explain query: %v", err)
+	}
+
+	for _, p := range e.SeqScans() {
+		if len(tables) == 0 {
+			t.Errorf("query scans %s sequentially: %s", p.RelationName, e.Query)
+			continue
+		}
+		for _, table := range tables {
+			if p.RelationName == table {
+				t.Errorf("query scans %s sequentially: %s", p.RelationName, e.Query)
+			}
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/sqlbunny/sqlbunny/runtime/bunny"
 
@@ -24,6 +25,10 @@ var (
 type MappedField struct {
 	Path        uint64
 	ParentValid *MappedField
+
+	// ValidFromColumns is set on the Valid field of a nullable struct that isn't
+	// bound to a column. It's set to true when any of the struct columns is not NULL.
+	ValidFromColumns bool
 }
 
 // Identifies what kind of object we're binding to
@@ -53,6 +58,9 @@ const (
 //     of the inner fields.
 //   - If the ",null:valid_column_name" option is specified in addition to ",bind", the SQL boolean column
 //     "valid_column_name" is used to tell whether the nested struct is valid (not null) or not (null).
+//   - If the ",null" option is specified in addition to ",bind", the nested struct is valid if any of
+//     its columns is not NULL. Use it to bind the columns of a model from an outer join, for example
+//     `bunny:"author.,bind,null"` on a field of type NullUser.
 func Bind(rows *sql.Rows, obj interface{}) error {
 	structType, sliceType, singular, err := bindChecks(obj)
 	if err != nil {
@@ -82,12 +90,166 @@ func (q *Query) Bind(ctx context.Context, obj interface{}) error {
 	}
 
 	if len(q.load) != 0 {
-		return eagerLoad(ctx, q.load, obj, bkind)
+		return eagerLoad(ctx, q.load, q.loadMods, obj, bkind)
 	}
 
 	return nil
 }
 
+// BindGroups executes a query grouped with GroupBy, and binds the groups into obj,
+// which must be a pointer to a slice of structs. The group by expressions are selected
+// first, followed by the selected columns of the query, usually aggregates with an
+// alias matching a bunny tag of the struct, like "sum(price) as total".
+func (q *Query) BindGroups(ctx context.Context, obj interface{}) error {
+	if len(q.groupBy) == 0 {
+		return errors.New("bind groups: the query has no group by clauses")
+	}
+	_, _, bkind, err := bindChecks(obj)
+	if err != nil {
+		return err
+	}
+	if bkind == kindStruct {
+		return errors.Errorf("bind groups: obj type should be *[]Type or *[]*Type but was %q", reflect.TypeOf(obj).String())
+	}
+
+	cp := q.Clone()
+	cp.selectCols = append(append([]string(nil), q.groupBy...), q.selectCols...)
+	cp.load = nil
+	return cp.Bind(ctx, obj)
+}
+
+// DefaultBatchSize is the number of rows BindBatches binds at a time when size is not positive.
+const DefaultBatchSize = 100
+
+// batchCursors numbers the cursors BindBatches declares in transactions.
+var batchCursors atomic.Uint64
+
+// BindBatches executes the query and calls fn with the resulting rows, bound to new
+// structs of type T, in batches of at most size rows. Rows are read from the database
+// as they're needed, so only one batch is held in memory at a time.
+// The relationships set with Load are eager loaded for each batch.
+// If fn returns an error, the iteration is stopped and the error returned.
+//
+// In a transaction, where all the queries share one connection, the batches are
+// fetched from a cursor and read fully before calling fn, so queries can be run
+// while iterating.
+func BindBatches[T any](ctx context.Context, q *Query, size int, fn func([]*T) error) error {
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+	structType := reflect.TypeOf((*T)(nil)).Elem()
+	if structType.Kind() != reflect.Struct {
+		return errors.Errorf("obj type should be a struct but was %q", structType.String())
+	}
+
+	flush := func(batch []*T) error {
+		if len(q.load) != 0 {
+			if err := eagerLoad(ctx, q.load, q.loadMods, &batch, kindPtrSliceStruct); err != nil {
+				return err
+			}
+		}
+		return fn(batch)
+	}
+
+	if bunny.IsAtomic(ctx) {
+		return bindCursorBatches(ctx, q, size, structType, flush)
+	}
+
+	rows, err := q.Query(ctx)
+	if err != nil {
+		return errors.Errorf("bind failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	mapping, err := rowsBindMapping(rows, structType)
+	if err != nil {
+		return err
+	}
+
+	for {
+		batch, err := bindBatch[T](rows, mapping, size)
+		if err != nil {
+			return err
+		}
+		if len(batch) != 0 {
+			if err := flush(batch); err != nil {
+				return err
+			}
+		}
+		if len(batch) < size {
+			return nil
+		}
+	}
+}
+
+// bindCursorBatches runs BindBatches with a cursor, fetching size rows at a time.
+func bindCursorBatches[T any](ctx context.Context, q *Query, size int, structType reflect.Type, flush func([]*T) error) error {
+	name := fmt.Sprintf("bunny_batches_%d", batchCursors.Add(1))
+	query, args := buildQuery(q)
+	declare := fmt.Sprintf("DECLARE %s NO SCROLL CURSOR FOR %s", name, strings.TrimSuffix(query, ";"))
+	if _, err := bunny.Exec(ctx, declare, args...); err != nil {
+		return errors.Errorf("bind failed to execute query: %w", err)
+	}
+	defer func() {
+		// Closing fails if the transaction was aborted, the cursor is gone with it.
+		_, _ = bunny.Exec(ctx, "CLOSE "+name)
+	}()
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM %s", size, name)
+	var mapping []MappedField
+	for {
+		rows, err := bunny.Query(ctx, fetch)
+		if err != nil {
+			return errors.Errorf("bind failed to fetch rows: %w", err)
+		}
+		if mapping == nil {
+			if mapping, err = rowsBindMapping(rows, structType); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		batch, err := bindBatch[T](rows, mapping, size)
+		rows.Close()
+		if err != nil {
+			return err
+		}
+		if len(batch) != 0 {
+			if err := flush(batch); err != nil {
+				return err
+			}
+		}
+		if len(batch) < size {
+			return nil
+		}
+	}
+}
+
+func rowsBindMapping(rows *sql.Rows, structType reflect.Type) ([]MappedField, error) {
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, errors.Errorf("bind failed to get field names: %w", err)
+	}
+	return cachedBindMapping(structType, cols)
+}
+
+// bindBatch binds the next rows to new structs, up to size of them. It returns
+// less than size structs once the rows are exhausted.
+func bindBatch[T any](rows *sql.Rows, mapping []MappedField, size int) ([]*T, error) {
+	// A new slice each time, the previous batch may be retained by fn.
+	batch := make([]*T, 0, size)
+	for len(batch) < size && rows.Next() {
+		obj := new(T)
+		if err := rows.Scan(PtrsFromMapping(reflect.ValueOf(obj).Elem(), mapping)...); err != nil {
+			return nil, errors.Errorf("failed to bind pointers to obj: %w", err)
+		}
+		batch = append(batch, obj)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
 // bindChecks resolves information about the bind target, and errors if it's not an object
 // we can bind to.
 func bindChecks(obj interface{}) (structType reflect.Type, sliceType reflect.Type, bkind bindKind, err error) {
@@ -155,35 +317,9 @@ func bind(rows *sql.Rows, obj interface{}, structType, sliceType reflect.Type, b
 		ptrSlice = reflect.Indirect(reflect.ValueOf(obj))
 	}
 
-	var strMapping map[string]MappedField
-	var sok bool
-	var mapping []MappedField
-	var ok bool
-
-	typStr := structType.String()
-
-	mapKey := makeCacheKey(typStr, cols)
-	mut.RLock()
-	mapping, ok = bindingMaps[mapKey]
-	if !ok {
-		if strMapping, sok = structMaps[typStr]; !sok {
-			strMapping = MakeStructMapping(structType)
-		}
-	}
-	mut.RUnlock()
-
-	if !ok {
-		mapping, err = BindMapping(structType, strMapping, cols)
-		if err != nil {
-			return err
-		}
-
-		mut.Lock()
-		if !sok {
-			structMaps[typStr] = strMapping
-		}
-		bindingMaps[mapKey] = mapping
-		mut.Unlock()
+	mapping, err := cachedBindMapping(structType, cols)
+	if err != nil {
+		return err
 	}
 
 	var oneStruct reflect.Value
@@ -237,6 +373,43 @@ func bind(rows *sql.Rows, obj interface{}, structType, sliceType reflect.Type, b
 	return nil
 }
 
+// cachedBindMapping returns the mapping of the columns to the fields of the struct type,
+// caching it for later queries.
+func cachedBindMapping(structType reflect.Type, cols []string) ([]MappedField, error) {
+	var strMapping map[string]MappedField
+	var sok bool
+
+	typStr := structType.String()
+
+	mapKey := makeCacheKey(typStr, cols)
+	mut.RLock()
+	mapping, ok := bindingMaps[mapKey]
+	if !ok {
+		if strMapping, sok = structMaps[typStr]; !sok {
+			strMapping = MakeStructMapping(structType)
+		}
+	}
+	mut.RUnlock()
+
+	if ok {
+		return mapping, nil
+	}
+
+	mapping, err := BindMapping(structType, strMapping, cols)
+	if err != nil {
+		return nil, err
+	}
+
+	mut.Lock()
+	if !sok {
+		structMaps[typStr] = strMapping
+	}
+	bindingMaps[mapKey] = mapping
+	mut.Unlock()
+
+	return mapping, nil
+}
+
 // BindMapping creates a mapping that helps look up the pointer for the
 // field given.
 func BindMapping(typ reflect.Type, mapping map[string]MappedField, cols []string) ([]MappedField, error) {
@@ -286,6 +459,9 @@ func ValuesFromMapping(val reflect.Value, mapping []MappedField) []interface{} {
 
 type ignoreNullScan struct {
 	dest interface{}
+
+	// valid are the ValidFromColumns fields of the parent structs, set when value is not NULL.
+	valid []*bool
 }
 
 // Scan implements the Scanner interface.
@@ -293,12 +469,31 @@ func (v *ignoreNullScan) Scan(value interface{}) error {
 	if value == nil {
 		return convert.AssignNil(v.dest)
 	}
+	for _, valid := range v.valid {
+		*valid = true
+	}
 	return convert.Assign(v.dest, value)
 }
 
+// validPtrs returns pointers to the ValidFromColumns fields in the parent chain
+// of a mapping, resetting them to false so the scan can set them.
+func validPtrs(val reflect.Value, parent *MappedField) []*bool {
+	var res []*bool
+	for ; parent != nil; parent = parent.ParentValid {
+		if !parent.ValidFromColumns {
+			continue
+		}
+		valid := ptrFromMapping(val, MappedField{Path: parent.Path}, true).(*bool)
+		*valid = false
+		res = append(res, valid)
+	}
+	return res
+}
+
 // ptrFromMapping expects to be passed an addressable struct that it's looking
 // for things on.
 func ptrFromMapping(val reflect.Value, mapping MappedField, addressOf bool) interface{} {
+	root := val
 	if mapping.Path == 0 {
 		var ignored interface{}
 		return &ignored
@@ -329,7 +524,8 @@ func ptrFromMapping(val reflect.Value, mapping MappedField, addressOf bool) inte
 				// Go zero values instead of erroring (unless the field
 				// implements sql.Scanner, in which case it's used as usual)
 				return &ignoreNullScan{
-					dest: val.Interface(),
+					dest:  val.Interface(),
+					valid: validPtrs(root, mapping.ParentValid),
 				}
 			}
 			return val.Interface()
@@ -380,17 +576,20 @@ func makeStructMappingHelper(typ reflect.Type, prefix string, current MappedFiel
 		}
 
 		if tag.bind {
-			if len(tag.null) != 0 {
+			if len(tag.null) != 0 || tag.nullFromColumns {
 				// TODO autodiscover this
 				structFieldIdx := 0
 				validFieldIdx := 1
 
 				valid := MappedField{
-					Path:        current.Path | uint64(i+1)<<depth | (uint64(validFieldIdx+1) << (depth + 8)),
-					ParentValid: current.ParentValid,
+					Path:             current.Path | uint64(i+1)<<depth | (uint64(validFieldIdx+1) << (depth + 8)),
+					ParentValid:      current.ParentValid,
+					ValidFromColumns: tag.nullFromColumns,
 				}
 
-				fieldMaps[prefix+tag.null] = valid
+				if !tag.nullFromColumns {
+					fieldMaps[prefix+tag.null] = valid
+				}
 				next := MappedField{
 					Path:        current.Path | uint64(i+1)<<depth | (uint64(structFieldIdx+1) << (depth + 8)),
 					ParentValid: &valid,
@@ -418,6 +617,8 @@ type bunnyTag struct {
 	name    string
 	bind    bool
 	null    string
+
+	nullFromColumns bool
 }
 
 func getBunnyTag(field reflect.StructField) (bunnyTag, error) {
@@ -438,6 +639,8 @@ func getBunnyTag(field reflect.StructField) (bunnyTag, error) {
 	for _, flag := range parts[1:] {
 		if flag == "bind" {
 			res.bind = true
+		} else if flag == "null" {
+			res.nullFromColumns = true
 		} else if strings.HasPrefix(flag, "null:") {
 			res.null = strings.TrimPrefix(flag, "null:")
 		} else {
@@ -445,7 +648,7 @@ func getBunnyTag(field reflect.StructField) (bunnyTag, error) {
 		}
 	}
 
-	if len(res.null) != 0 && !res.bind {
+	if (len(res.null) != 0 || res.nullFromColumns) && !res.bind {
 		return bunnyTag{}, fmt.Errorf("Invalid flags in bunny tag in field '%s': null requires bind to be set", field.Name)
 	}
 
@@ -179,6 +179,161 @@ func TestBindPtrSlice(t *testing.T) {
 	}
 }
 
+func TestBindBatches(t *testing.T) {
+	t.Parallel()
+
+	type fun struct {
+		ID   int    `bunny:"id"`
+		Name string `bunny:"test"`
+	}
+
+	query := &Query{
+		from:    []string{"fun"},
+		dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	ret := sqlmock.NewRows([]string{"id", "test"})
+	ret.AddRow(driver.Value(int64(35)), driver.Value("pat"))
+	ret.AddRow(driver.Value(int64(12)), driver.Value("cat"))
+	ret.AddRow(driver.Value(int64(7)), driver.Value("hat"))
+	mock.ExpectQuery(`SELECT \* FROM "fun";`).WillReturnRows(ret)
+
+	var batches [][]*fun
+	ctx := dbToContext(db)
+	err = BindBatches(ctx, query, 2, func(batch []*fun) error {
+		batches = append(batches, batch)
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(batches) != 2 || len(batches[0]) != 2 || len(batches[1]) != 1 {
+		t.Fatal("wrong batches:", batches)
+	}
+	if id := batches[0][1].ID; id != 12 {
+		t.Error("wrong ID:", id)
+	}
+	if name := batches[1][0].Name; name != "hat" {
+		t.Error("wrong name:", name)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBindBatchesAtomic(t *testing.T) {
+	t.Parallel()
+
+	type atomicFun struct {
+		ID int `bunny:"id"`
+	}
+
+	query := &Query{
+		from:    []string{"fun"},
+		where:   []where{{clause: "id > ?", args: []interface{}{1}}},
+		dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Each batch is read before fn runs its query.
+	mock.ExpectBegin()
+	mock.ExpectExec(`^DECLARE bunny_batches_\d+ NO SCROLL CURSOR FOR SELECT \* FROM "fun" WHERE \(id > \$1\)$`).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`^FETCH FORWARD 2 FROM bunny_batches_\d+$`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(3))
+	mock.ExpectExec(`^UPDATE fun`).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(`^FETCH FORWARD 2 FROM bunny_batches_\d+$`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	mock.ExpectExec(`^UPDATE fun`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`^CLOSE bunny_batches_\d+$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	var ids []int
+	err = bunny.Atomic(dbToContext(db), func(ctx context.Context) error {
+		return BindBatches(ctx, query, 2, func(batch []*atomicFun) error {
+			for _, f := range batch {
+				ids = append(ids, f.ID)
+			}
+			_, err := bunny.Exec(ctx, "UPDATE fun SET seen = true")
+			return err
+		})
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(ids, []int{2, 3, 4}) {
+		t.Error("wrong ids:", ids)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBindGroups(t *testing.T) {
+	t.Parallel()
+
+	var testResults []struct {
+		AuthorID int   `bunny:"author_id"`
+		Total    int64 `bunny:"total"`
+	}
+
+	query := &Query{
+		selectCols: []string{"sum(price) as total"},
+		from:       []string{"books"},
+		groupBy:    []string{"author_id"},
+		having:     []having{{clause: "sum(price) > ?", args: []interface{}{10}}},
+		dialect:    &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	ret := sqlmock.NewRows([]string{"author_id", "total"})
+	ret.AddRow(driver.Value(int64(1)), driver.Value(int64(30)))
+	ret.AddRow(driver.Value(int64(2)), driver.Value(int64(12)))
+	mock.ExpectQuery(`SELECT "author_id", sum\(price\) as total FROM "books" GROUP BY author_id HAVING sum\(price\) > \$1;`).WithArgs(10).WillReturnRows(ret)
+
+	ctx := dbToContext(db)
+	err = query.BindGroups(ctx, &testResults)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(testResults) != 2 {
+		t.Fatal("wrong number of results:", len(testResults))
+	}
+	if r := testResults[1]; r.AuthorID != 2 || r.Total != 12 {
+		t.Error("wrong result:", r)
+	}
+	if len(query.selectCols) != 1 {
+		t.Error("query was modified:", query.selectCols)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	var one struct{}
+	if err := query.BindGroups(ctx, &one); err == nil {
+		t.Error("expected an error binding groups into a struct")
+	}
+	if err := (&Query{}).BindGroups(ctx, &testResults); err == nil {
+		t.Error("expected an error binding groups without group by")
+	}
+}
+
 func testMakeMapping(byt ...byte) MappedField {
 	var x uint64
 	for i, b := range byt {
@@ -397,7 +552,9 @@ func TestGetBunnyTag(t *testing.T) {
 		LastName    string `bunny:"test_two"`
 		MiddleName  string `bunny:"middle_name,bind"`
 		AwesomeName string `bunny:"awesome_name,bind,null:awesome_name_null"`
+		JoinedName  string `bunny:"joined.,bind,null"`
 		Age         string `bunny:"age,null:agenull"`
+		Eyes        string `bunny:"eyes,null"`
 		Nose        string
 		Fail        string `bunny:"fail,invalidflag"`
 	}
@@ -414,7 +571,9 @@ func TestGetBunnyTag(t *testing.T) {
 	structFields = append(structFields, removeOk(typ.FieldByName("LastName")))
 	structFields = append(structFields, removeOk(typ.FieldByName("MiddleName")))
 	structFields = append(structFields, removeOk(typ.FieldByName("AwesomeName")))
+	structFields = append(structFields, removeOk(typ.FieldByName("JoinedName")))
 	structFields = append(structFields, removeOk(typ.FieldByName("Age")))
+	structFields = append(structFields, removeOk(typ.FieldByName("Eyes")))
 	structFields = append(structFields, removeOk(typ.FieldByName("Nose")))
 	structFields = append(structFields, removeOk(typ.FieldByName("Fail")))
 
@@ -423,6 +582,8 @@ func TestGetBunnyTag(t *testing.T) {
 		{present: true, name: "test_two"},
 		{present: true, name: "middle_name", bind: true},
 		{present: true, name: "awesome_name", bind: true, null: "awesome_name_null"},
+		{present: true, name: "joined.", bind: true, nullFromColumns: true},
+		nil,
 		nil,
 		{present: false},
 		nil,
@@ -793,3 +954,77 @@ func TestBind_NullBind(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestBind_LeftJoinNull(t *testing.T) {
+	t.Parallel()
+
+	type nullHappy struct {
+		Happy struct {
+			ID   int    `bunny:"id"`
+			Name string `bunny:"name"`
+		}
+		Valid bool
+	}
+
+	testResults := []*struct {
+		Fun struct {
+			ID int `bunny:"id"`
+		} `bunny:"fun.,bind"`
+		Happy nullHappy `bunny:"h.,bind,null"`
+	}{}
+
+	query := &Query{
+		dialect:    &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+		selectCols: []string{"fun.id", "h.id", "h.name"},
+		from:       []string{"fun"},
+		joins:      []join{{kind: JoinOuterLeft, clause: "happy as h on fun.happy_id = h.id"}},
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	ret := sqlmock.NewRows([]string{"fun.id", "h.id", "h.name"})
+	ret.AddRow(driver.Value(int64(10)), driver.Value(int64(11)), driver.Value("a"))
+	ret.AddRow(driver.Value(int64(12)), driver.Value(nil), driver.Value(nil))
+	ret.AddRow(driver.Value(int64(13)), driver.Value(int64(14)), driver.Value(nil))
+	mock.ExpectQuery(`SELECT "fun"."id" as "fun.id", "h"."id" as "h.id", "h"."name" as "h.name" FROM "fun" LEFT JOIN happy as h on fun.happy_id = h.id;`).WillReturnRows(ret)
+
+	ctx := dbToContext(db)
+	err = query.Bind(ctx, &testResults)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(testResults) != 3 {
+		t.Fatal("wrong number of results:", len(testResults))
+	}
+	if valid := testResults[0].Happy.Valid; valid != true {
+		t.Error("wrong valid:", valid)
+	}
+	if id := testResults[0].Happy.Happy.ID; id != 11 {
+		t.Error("wrong ID:", id)
+	}
+	if name := testResults[0].Happy.Happy.Name; name != "a" {
+		t.Error("wrong name:", name)
+	}
+
+	if valid := testResults[1].Happy.Valid; valid != false {
+		t.Error("wrong valid:", valid)
+	}
+	if id := testResults[1].Fun.ID; id != 12 {
+		t.Error("wrong ID:", id)
+	}
+
+	if valid := testResults[2].Happy.Valid; valid != true {
+		t.Error("wrong valid:", valid)
+	}
+	if id := testResults[2].Happy.Happy.ID; id != 14 {
+		t.Error("wrong ID:", id)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
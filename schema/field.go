@@ -1,5 +1,11 @@
 package schema
 
+import (
+	"strings"
+
+	"github.com/sqlbunny/sqlbunny/runtime/strmangle"
+)
+
 // Field holds information about a database field.
 // Types are Go types, converted by TranslateFieldType.
 type Field struct {
@@ -7,6 +13,10 @@ type Field struct {
 	Type     Type
 	Nullable bool
 
+	// JSON is set if the field has struct type and is stored as a single
+	// JSON column instead of being flattened into one column per struct field.
+	JSON bool
+
 	Tags Tags
 
 	Extendable
@@ -15,7 +25,7 @@ type Field struct {
 func (f *Field) GenerateTags() string {
 	if _, ok := f.Tags["bunny"]; !ok {
 		f.Tags["bunny"] = f.Name
-		if f.IsStruct() {
+		if f.IsStruct() && !f.IsJSON() {
 			f.Tags["bunny"] += "__,bind"
 			if f.Nullable {
 				f.Tags["bunny"] += ",null:" + f.Name
@@ -28,6 +38,29 @@ func (f *Field) GenerateTags() string {
 	return f.Tags.String()
 }
 
+// JSONName returns the JSON object key the field is marshaled to, as
+// set by the json tag in GenerateTags. ok is false if the field is
+// not marshaled at all.
+func (f *Field) JSONName() (name string, omitEmpty bool, ok bool) {
+	f.GenerateTags()
+
+	parts := strings.Split(f.Tags["json"], ",")
+	if parts[0] == "-" && len(parts) == 1 {
+		return "", false, false
+	}
+
+	name = parts[0]
+	if name == "" {
+		name = strmangle.TitleCase(f.Name)
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, true
+}
+
 func (f *Field) HasTag(tag string) bool {
 	_, ok := f.Tags[tag]
 	return ok
@@ -38,6 +71,16 @@ func (f *Field) IsStruct() bool {
 	return ok
 }
 
+// IsJSON returns true if the field is stored as a single JSON column, either because
+// the field is marked as such or because its struct type is.
+func (f *Field) IsJSON() bool {
+	if f.JSON {
+		return true
+	}
+	s, ok := f.Type.(*Struct)
+	return ok && s.JSON
+}
+
 func (f *Field) GoType() GoType {
 	if f.Nullable {
 		return f.Type.(NullableType).GoTypeNull()
@@ -3,6 +3,8 @@ package schema
 // PrimaryKey represents a primary key in a database
 type PrimaryKey struct {
 	Fields []Path
+
+	Extendable
 }
 
 // Index represents an index in a database
@@ -10,11 +12,15 @@ type Index struct {
 	Fields []Path
 	Method  string   // Index method. If empty, default is btree.
 	Where   string   // Index where clause, for partial indexes. If empty, no where clause is in effect.
+
+	Extendable
 }
 
 // Unique represents a unique constraint in a database
 type Unique struct {
 	Fields []Path
+
+	Extendable
 }
 
 // ForeignKey represents a foreign key constraint in a database
@@ -22,4 +28,6 @@ type ForeignKey struct {
 	LocalFields   []Path
 	ForeignModel  string
 	ForeignFields []Path
+
+	Extendable
 }
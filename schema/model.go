@@ -8,6 +8,7 @@ type Model struct {
 	Fields []*Field
 
 	PrimaryKey  *PrimaryKey
+	PaginateBy  []Path
 	Indexes     []*Index
 	Uniques     []*Unique
 	ForeignKeys []*ForeignKey
@@ -30,7 +31,7 @@ func (m *Model) FindField(path Path) *Field {
 	f := m.fieldByName(path[0])
 
 	for _, name := range path[1:] {
-		if f == nil {
+		if f == nil || f.IsJSON() {
 			return nil
 		}
 
@@ -53,6 +54,45 @@ func (m *Model) fieldByName(name string) *Field {
 	return nil
 }
 
+// PaginationFields returns the fields keyset pagination orders by,
+// which are the primary key fields unless PaginateBy is set.
+func (m *Model) PaginationFields() []Path {
+	if m.PaginateBy != nil {
+		return m.PaginateBy
+	}
+	if m.PrimaryKey == nil {
+		return nil
+	}
+	return m.PrimaryKey.Fields
+}
+
+// HasForeignKey returns whether fields of m reference foreignModel with a foreign key.
+func (m *Model) HasForeignKey(fields []Path, foreignModel string) bool {
+	for _, f := range m.ForeignKeys {
+		if f.ForeignModel == foreignModel && pathsEqual(f.LocalFields, fields) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindReverseRelationship returns the relationship of m going back through r,
+// a relationship of the model named model. Returns nil if not found.
+func (m *Model) FindReverseRelationship(model string, r *Relationship) *Relationship {
+	for _, r2 := range m.Relationships {
+		if r2.ForeignModel == model &&
+			r2.IsJoinModel == r.IsJoinModel &&
+			r2.JoinModel == r.JoinModel &&
+			pathsEqual(r2.LocalFields, r.ForeignFields) &&
+			pathsEqual(r2.ForeignFields, r.LocalFields) &&
+			pathsEqual(r2.JoinLocalFields, r.JoinForeignFields) &&
+			pathsEqual(r2.JoinForeignFields, r.JoinLocalFields) {
+			return r2
+		}
+	}
+	return nil
+}
+
 func (m *Model) IsFieldsUnique(fields []Path) bool {
 	if m.PrimaryKey != nil && isSubset(m.PrimaryKey.Fields, fields) {
 		return true
@@ -80,3 +120,15 @@ func isSubset(a, b []Path) bool {
 	}
 	return true
 }
+
+func pathsEqual(a, b []Path) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equals(b[i]) {
+			return false
+		}
+	}
+	return true
+}
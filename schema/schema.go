@@ -2,6 +2,7 @@ package schema
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/sqlbunny/sqlbunny/runtime/strmangle"
@@ -21,6 +22,26 @@ func New() *Schema {
 	}
 }
 
+// SortedTypeNames returns the names of the types of the schema, sorted.
+func (s *Schema) SortedTypeNames() []string {
+	var res []string
+	for name := range s.Types {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// SortedModelNames returns the names of the models of the schema, sorted.
+func (s *Schema) SortedModelNames() []string {
+	var res []string
+	for name := range s.Models {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
 func (s *Schema) CalculateRelationships() {
 	// Figure out which models are join models
 	for _, m := range s.Models {
@@ -86,6 +107,33 @@ func (s *Schema) CalculateRelationships() {
 	}
 }
 
+// IsStoredAsJSON returns whether values of struct type st are stored as a single JSON
+// column anywhere in the schema, because st or a field of its type is marked as such.
+func (s *Schema) IsStoredAsJSON(st *Struct) bool {
+	if st.JSON {
+		return true
+	}
+	hasJSONField := func(fields []*Field) bool {
+		for _, f := range fields {
+			if t, ok := f.Type.(*Struct); ok && t == st && f.JSON {
+				return true
+			}
+		}
+		return false
+	}
+	for _, m := range s.Models {
+		if hasJSONField(m.Fields) {
+			return true
+		}
+	}
+	for _, t := range s.Types {
+		if t, ok := t.(*Struct); ok && hasJSONField(t.Fields) {
+			return true
+		}
+	}
+	return false
+}
+
 // isJoinModel autodetects if t is a join model. A model is a join model if all are true:
 // - All the columns are part of the primary key
 // - There are exactly 2 foreign keys
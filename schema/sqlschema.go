@@ -101,6 +101,22 @@ func (s *Schema) SQLSchema() *schema.Database {
 }
 
 func doCalcFields(m *Model, t *schema.Table, f *Field, forceNullable bool, prefix Path) {
+	if f.IsJSON() {
+		nullable := f.Nullable || forceNullable
+		var def string
+		if !nullable {
+			def = "'{}'"
+		}
+
+		colName := appendPath(prefix, f.Name).SQLName()
+		t.Columns[colName] = &schema.Column{
+			Type:     "jsonb",
+			Default:  def,
+			Nullable: nullable,
+		}
+		return
+	}
+
 	switch ty := f.Type.(type) {
 	case *Struct:
 		forceNullable2 := forceNullable || f.Nullable
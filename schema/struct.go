@@ -8,6 +8,9 @@ type Struct struct {
 	Name   string
 	Fields []*Field
 
+	// JSON is set if fields of this type are stored as a single JSON column by default.
+	JSON bool
+
 	Extendable
 }
 